@@ -0,0 +1,281 @@
+package delayq
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var emptyDelayQueueError error
+
+func init() {
+	emptyDelayQueueError = errors.New("empty delay queue")
+}
+
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+type Handle[T any] struct {
+	value   T
+	readyAt time.Time
+	seq     uint64
+	index   int
+}
+
+func (h *Handle[T]) Value() T {
+	return h.value
+}
+
+func (h *Handle[T]) ReadyAt() time.Time {
+	return h.readyAt
+}
+
+type DelayQueue[T any] struct {
+	mu    sync.Mutex
+	heap  []*Handle[T]
+	clock Clock
+	wake  chan struct{}
+	seq   uint64
+}
+
+func New[T any](clock ...Clock) *DelayQueue[T] {
+	var c Clock = systemClock{}
+
+	if len(clock) > 0 && clock[0] != nil {
+		c = clock[0]
+	}
+
+	return &DelayQueue[T]{clock: c, wake: make(chan struct{})}
+}
+
+func (q *DelayQueue[T]) Enqueue(value T, readyAt time.Time) *Handle[T] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	handle := &Handle[T]{value, readyAt, q.seq, len(q.heap)}
+	q.seq++
+	q.heap = append(q.heap, handle)
+	q.heapifyUp(handle.index)
+
+	if handle.index == 0 {
+		q.broadcast()
+	}
+
+	return handle
+}
+
+func (q *DelayQueue[T]) EnqueueAfter(value T, delay time.Duration) *Handle[T] {
+	return q.Enqueue(value, q.clock.Now().Add(delay))
+}
+
+func (q *DelayQueue[T]) Cancel(handle *Handle[T]) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if handle.index < 0 || handle.index >= len(q.heap) || q.heap[handle.index] != handle {
+		return false
+	}
+
+	wasFirst := handle.index == 0
+	q.removeAt(handle.index)
+
+	if wasFirst {
+		q.broadcast()
+	}
+
+	return true
+}
+
+func (q *DelayQueue[T]) Dequeue(ctx context.Context) (T, error) {
+	for {
+		q.mu.Lock()
+
+		if len(q.heap) > 0 && !q.heap[0].readyAt.After(q.clock.Now()) {
+			handle := q.removeAt(0)
+			q.mu.Unlock()
+			return handle.value, nil
+		}
+
+		wake := q.wake
+		var timer <-chan time.Time
+
+		if len(q.heap) > 0 {
+			timer = q.clock.After(q.heap[0].readyAt.Sub(q.clock.Now()))
+		}
+
+		q.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		case <-wake:
+		case <-timer:
+		}
+	}
+}
+
+func (q *DelayQueue[T]) TryDequeue() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.heap) == 0 || q.heap[0].readyAt.After(q.clock.Now()) {
+		var zero T
+		return zero, false
+	}
+
+	return q.removeAt(0).value, true
+}
+
+func (q *DelayQueue[T]) Peek() (time.Time, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.heap) == 0 {
+		return time.Time{}, emptyDelayQueueError
+	}
+
+	return q.heap[0].readyAt, nil
+}
+
+func (q *DelayQueue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, handle := range q.heap {
+		handle.index = -1
+	}
+
+	q.heap = q.heap[:0]
+	q.broadcast()
+}
+
+func (q *DelayQueue[T]) IsEmpty() bool {
+	return q.Size() == 0
+}
+
+func (q *DelayQueue[T]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.heap)
+}
+
+func (q *DelayQueue[T]) String() string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var builder strings.Builder
+	builder.WriteString("DelayQueue[")
+
+	sorted := slices.Clone(q.heap)
+	slices.SortFunc(sorted, compareHandles)
+
+	for i, handle := range sorted {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+
+		builder.WriteString(fmt.Sprintf("(%v:%s)", handle.value, handle.readyAt.Format(time.RFC3339Nano)))
+	}
+
+	builder.WriteString("]")
+	return builder.String()
+}
+
+func (q *DelayQueue[T]) broadcast() {
+	close(q.wake)
+	q.wake = make(chan struct{})
+}
+
+func compareHandles[T any](lhs *Handle[T], rhs *Handle[T]) int {
+	if c := lhs.readyAt.Compare(rhs.readyAt); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(lhs.seq, rhs.seq)
+}
+
+func (q *DelayQueue[T]) less(i int, j int) bool {
+	return compareHandles(q.heap[i], q.heap[j]) < 0
+}
+
+func (q *DelayQueue[T]) swap(i int, j int) {
+	q.heap[i], q.heap[j] = q.heap[j], q.heap[i]
+	q.heap[i].index = i
+	q.heap[j].index = j
+}
+
+func (q *DelayQueue[T]) removeAt(index int) *Handle[T] {
+	last := len(q.heap) - 1
+	handle := q.heap[index]
+
+	if index != last {
+		q.swap(index, last)
+	}
+
+	q.heap[last] = nil
+	q.heap = q.heap[:last]
+	handle.index = -1
+
+	if index < last {
+		q.heapifyDown(index)
+		q.heapifyUp(index)
+	}
+
+	return handle
+}
+
+func (q *DelayQueue[T]) heapifyUp(curr int) {
+	for curr > 0 {
+		parent := (curr - 1) >> 1
+
+		if !q.less(curr, parent) {
+			break
+		}
+
+		q.swap(curr, parent)
+		curr = parent
+	}
+}
+
+func (q *DelayQueue[T]) heapifyDown(curr int) {
+	size := len(q.heap)
+
+	for {
+		opt := curr
+		left := (curr << 1) + 1
+		right := left + 1
+
+		if left < size && q.less(left, opt) {
+			opt = left
+		}
+
+		if right < size && q.less(right, opt) {
+			opt = right
+		}
+
+		if curr == opt {
+			break
+		}
+
+		q.swap(curr, opt)
+		curr = opt
+	}
+}
@@ -0,0 +1,276 @@
+package delayq
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeTimer struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []fakeTimer
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+
+	c.timers = append(c.timers, fakeTimer{c.now.Add(d), ch})
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	pending := c.timers[:0]
+
+	for _, timer := range c.timers {
+		if timer.deadline.After(c.now) {
+			pending = append(pending, timer)
+		} else {
+			timer.ch <- c.now
+		}
+	}
+
+	c.timers = pending
+}
+
+func (c *fakeClock) waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+func TestDelayQueueEnqueuePeekTryDequeue(t *testing.T) {
+	clock := newFakeClock()
+	q := New[string](clock)
+
+	_, err := q.Peek()
+
+	if err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	start := clock.Now()
+	q.EnqueueAfter("b", 2*time.Second)
+	q.EnqueueAfter("a", time.Second)
+	q.EnqueueAfter("c", 3*time.Second)
+	q.Enqueue("a2", start.Add(time.Second))
+
+	next, err := q.Peek()
+
+	if err != nil {
+		t.Errorf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	if !next.Equal(start.Add(time.Second)) {
+		t.Errorf("Expected %v, got %v instead", start.Add(time.Second), next)
+	}
+
+	if _, ok := q.TryDequeue(); ok {
+		t.Error("Expected no ready item")
+	}
+
+	clock.Advance(2 * time.Second)
+
+	for _, expected := range []string{"a", "a2", "b"} {
+		value, ok := q.TryDequeue()
+
+		if !ok || value != expected {
+			t.Errorf("Expected %s, got %s (%v) instead", expected, value, ok)
+		}
+	}
+
+	if _, ok := q.TryDequeue(); ok {
+		t.Error("Expected no ready item")
+	}
+
+	if q.Size() != 1 {
+		t.Errorf("Expected size 1, got %d instead", q.Size())
+	}
+
+	q.Clear()
+
+	if !q.IsEmpty() {
+		t.Errorf("Expected size 0, got %d instead", q.Size())
+	}
+}
+
+func TestDelayQueueCancel(t *testing.T) {
+	clock := newFakeClock()
+	q := New[int](clock)
+
+	handles := make([]*Handle[int], 0, 10)
+
+	for i := range 10 {
+		handles = append(handles, q.EnqueueAfter(i, time.Duration(i)*time.Second))
+	}
+
+	if handles[3].Value() != 3 || !handles[3].ReadyAt().Equal(clock.Now().Add(3*time.Second)) {
+		t.Error("Handle does not report its value and ready time")
+	}
+
+	for _, i := range []int{0, 3, 7} {
+		if !q.Cancel(handles[i]) {
+			t.Errorf("Expected handle %d to be cancelled", i)
+		}
+	}
+
+	if q.Cancel(handles[3]) {
+		t.Error("Expected a second cancellation to fail")
+	}
+
+	clock.Advance(time.Minute)
+	var values []int
+
+	for {
+		value, ok := q.TryDequeue()
+
+		if !ok {
+			break
+		}
+
+		values = append(values, value)
+	}
+
+	expected := []int{1, 2, 4, 5, 6, 8, 9}
+
+	if len(values) != len(expected) {
+		t.Fatalf("Expected %v, got %v instead", expected, values)
+	}
+
+	for i := range expected {
+		if values[i] != expected[i] {
+			t.Errorf("Expected %v, got %v instead", expected, values)
+			break
+		}
+	}
+
+	if q.Cancel(handles[1]) {
+		t.Error("Expected cancelling a dequeued item to fail")
+	}
+}
+
+func TestDelayQueueBlockingDequeue(t *testing.T) {
+	clock := newFakeClock()
+	q := New[string](clock)
+	q.EnqueueAfter("late", 10*time.Second)
+
+	result := make(chan string)
+
+	go func() {
+		value, err := q.Dequeue(context.Background())
+
+		if err != nil {
+			t.Errorf("Expected no error, got '%s' instead", err.Error())
+		}
+
+		result <- value
+	}()
+
+	for clock.waiters() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	q.EnqueueAfter("early", 5*time.Second)
+
+	for clock.waiters() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+
+	clock.Advance(5 * time.Second)
+
+	if value := <-result; value != "early" {
+		t.Errorf("Expected early, got %s instead", value)
+	}
+
+	if q.Size() != 1 {
+		t.Errorf("Expected size 1, got %d instead", q.Size())
+	}
+}
+
+func TestDelayQueueDequeueCancellation(t *testing.T) {
+	clock := newFakeClock()
+	q := New[int](clock)
+	q.EnqueueAfter(1, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	go func() {
+		_, err := q.Dequeue(ctx)
+		done <- err
+	}()
+
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v instead", err)
+	}
+
+	empty := New[int](clock)
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := empty.Dequeue(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v instead", err)
+	}
+}
+
+func TestDelayQueueSystemClock(t *testing.T) {
+	q := New[int]()
+	q.EnqueueAfter(1, 5*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	value, err := q.Dequeue(ctx)
+
+	if err != nil {
+		t.Errorf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	if value != 1 {
+		t.Errorf("Expected 1, got %d instead", value)
+	}
+}
+
+func TestDelayQueueString(t *testing.T) {
+	clock := newFakeClock()
+	q := New[string](clock)
+	q.EnqueueAfter("b", time.Second)
+	q.EnqueueAfter("a", 0)
+
+	expected := "DelayQueue[(a:2024-01-01T00:00:00Z), (b:2024-01-01T00:00:01Z)]"
+
+	if q.String() != expected {
+		t.Errorf("Expected '%s', got '%s' instead", expected, q.String())
+	}
+}
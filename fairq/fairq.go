@@ -0,0 +1,206 @@
+package fairq
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/XeniaPhe/xengods/pqueue"
+)
+
+var emptyFairQueueError error
+var limitExceededError error
+
+func init() {
+	emptyFairQueueError = errors.New("empty fair queue")
+	limitExceededError = errors.New("per-key limit exceeded")
+}
+
+type tenant[T any] struct {
+	pq      pqueue.PQueue[T]
+	weight  int
+	limit   int
+	deficit int
+	active  bool
+}
+
+type FairQueue[K comparable, T any] struct {
+	tenants     map[K]*tenant[T]
+	active      []K
+	cursor      int
+	turnStarted bool
+	size        int
+	min         bool
+}
+
+func New[K comparable, T any](min bool) *FairQueue[K, T] {
+	return &FairQueue[K, T]{tenants: make(map[K]*tenant[T]), min: min}
+}
+
+func (f *FairQueue[K, T]) SetWeight(key K, weight int) {
+	f.tenant(key).weight = max(weight, 1)
+}
+
+func (f *FairQueue[K, T]) Weight(key K) int {
+	if t, found := f.tenants[key]; found {
+		return t.weight
+	}
+
+	return 1
+}
+
+func (f *FairQueue[K, T]) SetLimit(key K, limit int) {
+	f.tenant(key).limit = max(limit, 0)
+}
+
+func (f *FairQueue[K, T]) Limit(key K) int {
+	if t, found := f.tenants[key]; found {
+		return t.limit
+	}
+
+	return 0
+}
+
+func (f *FairQueue[K, T]) Enqueue(key K, value T, priority int32) error {
+	t := f.tenant(key)
+
+	if t.limit > 0 && t.pq.Size() >= t.limit {
+		return limitExceededError
+	}
+
+	t.pq.Enqueue(value, priority)
+	f.size++
+
+	if !t.active {
+		t.active = true
+		t.deficit = 0
+		f.active = append(f.active, key)
+	}
+
+	return nil
+}
+
+func (f *FairQueue[K, T]) Dequeue() (K, pqueue.Item[T], error) {
+	if len(f.active) == 0 {
+		var zero K
+		return zero, pqueue.Item[T]{}, emptyFairQueueError
+	}
+
+	for {
+		key := f.active[f.cursor]
+		t := f.tenants[key]
+
+		if !f.turnStarted {
+			t.deficit += t.weight
+			f.turnStarted = true
+		}
+
+		if t.deficit <= 0 {
+			f.advance()
+			continue
+		}
+
+		item, _ := t.pq.Dequeue()
+		t.deficit--
+		f.size--
+
+		if t.pq.IsEmpty() {
+			f.deactivate()
+		}
+
+		return key, item, nil
+	}
+}
+
+func (f *FairQueue[K, T]) Peek() (K, pqueue.Item[T], error) {
+	if len(f.active) == 0 {
+		var zero K
+		return zero, pqueue.Item[T]{}, emptyFairQueueError
+	}
+
+	cursor := f.cursor
+	key := f.active[cursor]
+	t := f.tenants[key]
+
+	if f.turnStarted && t.deficit <= 0 {
+		key = f.active[(cursor+1)%len(f.active)]
+	}
+
+	item, _ := f.tenants[key].pq.Peek()
+	return key, item, nil
+}
+
+func (f *FairQueue[K, T]) Remove(key K) {
+	t, found := f.tenants[key]
+
+	if !found {
+		return
+	}
+
+	f.size -= t.pq.Size()
+
+	if t.active {
+		index := slices.Index(f.active, key)
+
+		if index == f.cursor {
+			f.deactivate()
+		} else {
+			f.active = slices.Delete(f.active, index, index+1)
+
+			if index < f.cursor {
+				f.cursor--
+			}
+		}
+	}
+
+	delete(f.tenants, key)
+}
+
+func (f *FairQueue[K, T]) Keys() []K {
+	return slices.Clone(f.active)
+}
+
+func (f *FairQueue[K, T]) SizeOf(key K) int {
+	if t, found := f.tenants[key]; found {
+		return t.pq.Size()
+	}
+
+	return 0
+}
+
+func (f *FairQueue[K, T]) Size() int {
+	return f.size
+}
+
+func (f *FairQueue[K, T]) IsEmpty() bool {
+	return f.size == 0
+}
+
+func (f *FairQueue[K, T]) tenant(key K) *tenant[T] {
+	t, found := f.tenants[key]
+
+	if !found {
+		t = &tenant[T]{pq: pqueue.NewPQueue[T](f.min), weight: 1}
+		f.tenants[key] = t
+	}
+
+	return t
+}
+
+func (f *FairQueue[K, T]) advance() {
+	f.cursor = (f.cursor + 1) % len(f.active)
+	f.turnStarted = false
+}
+
+func (f *FairQueue[K, T]) deactivate() {
+	t := f.tenants[f.active[f.cursor]]
+	t.active = false
+	t.deficit = 0
+	t.pq.Clear()
+
+	f.active = slices.Delete(f.active, f.cursor, f.cursor+1)
+	f.turnStarted = false
+
+	if f.cursor >= len(f.active) {
+		f.cursor = 0
+	}
+}
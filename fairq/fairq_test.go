@@ -0,0 +1,174 @@
+package fairq
+
+import (
+	"testing"
+)
+
+func TestFairQueueWeightedFairness(t *testing.T) {
+	f := New[string, int](false)
+	f.SetWeight("a", 1)
+	f.SetWeight("b", 2)
+	f.SetWeight("c", 3)
+
+	for i := range 100 {
+		f.Enqueue("a", i, int32(i))
+		f.Enqueue("b", i, int32(i))
+		f.Enqueue("c", i, int32(i))
+	}
+
+	counts := make(map[string]int)
+
+	for range 60 {
+		key, _, err := f.Dequeue()
+
+		if err != nil {
+			t.Fatalf("Expected no error, got '%s' instead", err.Error())
+		}
+
+		counts[key]++
+	}
+
+	if counts["a"] != 10 || counts["b"] != 20 || counts["c"] != 30 {
+		t.Errorf("Expected 10/20/30 split, got %d/%d/%d instead", counts["a"], counts["b"], counts["c"])
+	}
+
+	if f.Size() != 240 {
+		t.Errorf("Expected size 240, got %d instead", f.Size())
+	}
+}
+
+func TestFairQueueOrderWithinKey(t *testing.T) {
+	f := New[int, string](true)
+	f.Enqueue(1, "x3", 3)
+	f.Enqueue(1, "x1", 1)
+	f.Enqueue(2, "y2", 2)
+	f.Enqueue(1, "x2", 2)
+	f.Enqueue(2, "y1", 1)
+
+	expected := []struct {
+		key   int
+		value string
+	}{{1, "x1"}, {2, "y1"}, {1, "x2"}, {2, "y2"}, {1, "x3"}}
+
+	for _, exp := range expected {
+		peekedKey, peeked, err := f.Peek()
+
+		if err != nil {
+			t.Fatalf("Expected no error, got '%s' instead", err.Error())
+		}
+
+		key, item, _ := f.Dequeue()
+
+		if key != exp.key || item.Value != exp.value {
+			t.Errorf("Expected %d:%s, got %d:%s instead", exp.key, exp.value, key, item.Value)
+		}
+
+		if peekedKey != key || peeked != item {
+			t.Errorf("Expected peek %d:%v to match dequeue %d:%v", peekedKey, peeked, key, item)
+		}
+	}
+
+	if !f.IsEmpty() {
+		t.Errorf("Expected size 0, got %d instead", f.Size())
+	}
+
+	if _, _, err := f.Dequeue(); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	if _, _, err := f.Peek(); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+}
+
+func TestFairQueueLimits(t *testing.T) {
+	f := New[string, int](false)
+	f.SetLimit("a", 2)
+
+	if f.Limit("a") != 2 || f.Limit("b") != 0 {
+		t.Errorf("Expected limits 2 and 0, got %d and %d instead", f.Limit("a"), f.Limit("b"))
+	}
+
+	if err := f.Enqueue("a", 1, 1); err != nil {
+		t.Errorf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	if err := f.Enqueue("a", 2, 2); err != nil {
+		t.Errorf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	if err := f.Enqueue("a", 3, 3); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	for i := range 10 {
+		if err := f.Enqueue("b", i, int32(i)); err != nil {
+			t.Errorf("Expected no error, got '%s' instead", err.Error())
+		}
+	}
+
+	if f.SizeOf("a") != 2 || f.SizeOf("b") != 10 || f.SizeOf("c") != 0 {
+		t.Errorf("Expected sizes 2, 10 and 0, got %d, %d and %d instead", f.SizeOf("a"), f.SizeOf("b"), f.SizeOf("c"))
+	}
+
+	f.Dequeue()
+
+	if err := f.Enqueue("a", 3, 3); err != nil {
+		t.Errorf("Expected no error, got '%s' instead", err.Error())
+	}
+}
+
+func TestFairQueueLateJoinAndRemove(t *testing.T) {
+	f := New[string, int](false)
+
+	for i := range 5 {
+		f.Enqueue("a", i, 0)
+	}
+
+	key, _, _ := f.Dequeue()
+
+	if key != "a" {
+		t.Errorf("Expected key a, got %s instead", key)
+	}
+
+	f.Enqueue("b", 0, 0)
+	f.Enqueue("c", 0, 0)
+	seen := make(map[string]int)
+
+	for range 3 {
+		key, _, _ := f.Dequeue()
+		seen[key]++
+	}
+
+	if seen["a"] != 1 || seen["b"] != 1 || seen["c"] != 1 {
+		t.Errorf("Expected every key to be served once, got %v instead", seen)
+	}
+
+	if len(f.Keys()) != 1 || f.Keys()[0] != "a" {
+		t.Errorf("Expected only key a to be active, got %v instead", f.Keys())
+	}
+
+	f.Enqueue("d", 0, 0)
+	f.Remove("a")
+	f.Remove("missing")
+
+	if f.Size() != 1 {
+		t.Errorf("Expected size 1, got %d instead", f.Size())
+	}
+
+	key, _, _ = f.Dequeue()
+
+	if key != "d" {
+		t.Errorf("Expected key d, got %s instead", key)
+	}
+
+	if f.Weight("d") != 1 {
+		t.Errorf("Expected weight 1, got %d instead", f.Weight("d"))
+	}
+
+	f.SetWeight("d", 0)
+
+	if f.Weight("d") != 1 {
+		t.Errorf("Expected weight 1, got %d instead", f.Weight("d"))
+	}
+}
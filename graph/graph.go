@@ -0,0 +1,180 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var missingVertexError error
+var negativeWeightError error
+var notDirectedError error
+var notUndirectedError error
+var noPathError error
+var cycleError error
+
+func init() {
+	missingVertexError = errors.New("vertex not in graph")
+	negativeWeightError = errors.New("negative edge weight")
+	notDirectedError = errors.New("operation requires a directed graph")
+	notUndirectedError = errors.New("operation requires an undirected graph")
+	noPathError = errors.New("no path between vertices")
+	cycleError = errors.New("graph contains a cycle")
+}
+
+type Edge[V comparable] struct {
+	From V
+	To V
+	Weight int32
+}
+
+type Graph[V comparable] struct {
+	adjacency map[V][]Edge[V]
+	vertices []V
+	directed bool
+	edgeCount int
+}
+
+func NewDirected[V comparable]() *Graph[V] {
+	return &Graph[V]{adjacency: make(map[V][]Edge[V]), directed: true}
+}
+
+func NewUndirected[V comparable]() *Graph[V] {
+	return &Graph[V]{adjacency: make(map[V][]Edge[V])}
+}
+
+func New[V comparable](directed bool) *Graph[V] {
+	if directed {
+		return NewDirected[V]()
+	}
+
+	return NewUndirected[V]()
+}
+
+func (g *Graph[V]) IsDirected() bool {
+	return g.directed
+}
+
+func (g *Graph[V]) AddVertex(v V) bool {
+	if _, found := g.adjacency[v]; found {
+		return false
+	}
+
+	g.adjacency[v] = nil
+	g.vertices = append(g.vertices, v)
+	return true
+}
+
+func (g *Graph[V]) AddEdge(from V, to V, weight int32) {
+	g.AddVertex(from)
+	g.AddVertex(to)
+	g.adjacency[from] = append(g.adjacency[from], Edge[V]{from, to, weight})
+
+	if !g.directed && from != to {
+		g.adjacency[to] = append(g.adjacency[to], Edge[V]{to, from, weight})
+	}
+
+	g.edgeCount++
+}
+
+func (g *Graph[V]) RemoveEdge(from V, to V) bool {
+	if !g.removeArc(from, to) {
+		return false
+	}
+
+	if !g.directed && from != to {
+		g.removeArc(to, from)
+	}
+
+	g.edgeCount--
+	return true
+}
+
+func (g *Graph[V]) HasVertex(v V) bool {
+	_, found := g.adjacency[v]
+	return found
+}
+
+func (g *Graph[V]) HasEdge(from V, to V) bool {
+	for _, edge := range g.adjacency[from] {
+		if edge.To == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (g *Graph[V]) Vertices() []V {
+	return append([]V(nil), g.vertices...)
+}
+
+func (g *Graph[V]) Neighbors(v V) []Edge[V] {
+	return append([]Edge[V](nil), g.adjacency[v]...)
+}
+
+func (g *Graph[V]) Edges() []Edge[V] {
+	edges := make([]Edge[V], 0, g.edgeCount)
+	seen := make(map[V]struct{}, len(g.vertices))
+
+	for _, v := range g.vertices {
+		seen[v] = struct{}{}
+
+		for _, edge := range g.adjacency[v] {
+			if _, done := seen[edge.To]; g.directed || !done || edge.To == v {
+				edges = append(edges, edge)
+			}
+		}
+	}
+
+	return edges
+}
+
+func (g *Graph[V]) VertexCount() int {
+	return len(g.vertices)
+}
+
+func (g *Graph[V]) EdgeCount() int {
+	return g.edgeCount
+}
+
+func (g *Graph[V]) String() string {
+	var builder strings.Builder
+
+	if g.directed {
+		builder.WriteString("Directed")
+	} else {
+		builder.WriteString("Undirected")
+	}
+
+	builder.WriteString("Graph[")
+	arrow := " -- "
+
+	if g.directed {
+		arrow = " -> "
+	}
+
+	for i, edge := range g.Edges() {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+
+		builder.WriteString(fmt.Sprintf("%v%s%v:%d", edge.From, arrow, edge.To, edge.Weight))
+	}
+
+	builder.WriteString("]")
+	return builder.String()
+}
+
+func (g *Graph[V]) removeArc(from V, to V) bool {
+	edges := g.adjacency[from]
+
+	for i, edge := range edges {
+		if edge.To == to {
+			g.adjacency[from] = append(edges[:i], edges[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
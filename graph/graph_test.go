@@ -0,0 +1,59 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGraphEdges(t *testing.T) {
+	g := NewUndirected[string]()
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("b", "c", 2)
+	g.AddEdge("c", "c", 3)
+
+	if g.VertexCount() != 3 || g.EdgeCount() != 3 {
+		t.Errorf("Expected 3 vertices and 3 edges, got %d and %d instead", g.VertexCount(), g.EdgeCount())
+	}
+
+	if !g.HasEdge("b", "a") || g.HasEdge("a", "c") {
+		t.Error("Expected undirected edges to be stored in both directions only")
+	}
+
+	if s := g.String(); s != "UndirectedGraph[a -- b:1, b -- c:2, c -- c:3]" {
+		t.Errorf("Unexpected string: %s", s)
+	}
+
+	if !g.RemoveEdge("b", "a") || g.RemoveEdge("a", "b") || g.HasEdge("b", "a") || g.EdgeCount() != 2 {
+		t.Error("Expected the edge to be removed in both directions exactly once")
+	}
+
+	d := NewDirected[int]()
+	d.AddEdge(1, 2, 5)
+	d.AddVertex(3)
+
+	if d.AddVertex(3) || !d.HasEdge(1, 2) || d.HasEdge(2, 1) {
+		t.Error("Unexpected directed graph contents")
+	}
+
+	if !slices.Equal(d.Vertices(), []int{1, 2, 3}) || d.String() != "DirectedGraph[1 -> 2:5]" {
+		t.Errorf("Unexpected directed graph: %v %s", d.Vertices(), d)
+	}
+}
+
+func grid(width int, height int) *Graph[[2]int] {
+	g := NewUndirected[[2]int]()
+
+	for x := range width {
+		for y := range height {
+			if x+1 < width {
+				g.AddEdge([2]int{x, y}, [2]int{x + 1, y}, 1)
+			}
+
+			if y+1 < height {
+				g.AddEdge([2]int{x, y}, [2]int{x, y + 1}, 1)
+			}
+		}
+	}
+
+	return g
+}
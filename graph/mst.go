@@ -0,0 +1,129 @@
+package graph
+
+import (
+	"github.com/XeniaPhe/xengods/pqueue"
+	"github.com/XeniaPhe/xengods/set"
+)
+
+// Both Prim and Kruskal return a minimum spanning forest when the graph is
+// disconnected, i.e. one spanning tree per connected component.
+func Prim[V comparable](g *Graph[V]) ([]Edge[V], error) {
+	if g.directed {
+		return nil, notUndirectedError
+	}
+
+	tree := make([]Edge[V], 0, max(g.VertexCount()-1, 0))
+	visited := set.New[V](g.VertexCount())
+	frontier := pqueue.NewMinPQueue[Edge[V]]()
+
+	for _, root := range g.vertices {
+		if visited.Contains(root) {
+			continue
+		}
+
+		visited.Add(root)
+		enqueueEdges(&frontier, g.adjacency[root], visited)
+
+		for !frontier.IsEmpty() {
+			next, _ := frontier.Dequeue()
+			edge := next.Value
+
+			if visited.Contains(edge.To) {
+				continue
+			}
+
+			visited.Add(edge.To)
+			tree = append(tree, edge)
+			enqueueEdges(&frontier, g.adjacency[edge.To], visited)
+		}
+	}
+
+	return tree, nil
+}
+
+func Kruskal[V comparable](g *Graph[V]) ([]Edge[V], error) {
+	if g.directed {
+		return nil, notUndirectedError
+	}
+
+	edges := g.Edges()
+	items := make([]pqueue.Item[Edge[V]], 0, len(edges))
+
+	for _, edge := range edges {
+		items = append(items, pqueue.Item[Edge[V]]{Value: edge, Priority: edge.Weight})
+	}
+
+	tree := make([]Edge[V], 0, max(g.VertexCount()-1, 0))
+	frontier := pqueue.FromItems(true, items...)
+	forest := newDisjointSet[V](g.VertexCount())
+
+	for !frontier.IsEmpty() && len(tree) < g.VertexCount()-1 {
+		next, _ := frontier.Dequeue()
+		edge := next.Value
+
+		if forest.union(edge.From, edge.To) {
+			tree = append(tree, edge)
+		}
+	}
+
+	return tree, nil
+}
+
+func TotalWeight[V comparable](edges []Edge[V]) int64 {
+	var total int64
+
+	for _, edge := range edges {
+		total += int64(edge.Weight)
+	}
+
+	return total
+}
+
+func enqueueEdges[V comparable](frontier *pqueue.PQueue[Edge[V]], edges []Edge[V], visited set.Set[V]) {
+	for _, edge := range edges {
+		if !visited.Contains(edge.To) {
+			frontier.Enqueue(edge, edge.Weight)
+		}
+	}
+}
+
+type disjointSet[V comparable] struct {
+	parent map[V]V
+	rank map[V]int
+}
+
+func newDisjointSet[V comparable](size int) disjointSet[V] {
+	return disjointSet[V]{make(map[V]V, size), make(map[V]int, size)}
+}
+
+func (d disjointSet[V]) find(v V) V {
+	parent, found := d.parent[v]
+
+	if !found || parent == v {
+		return v
+	}
+
+	root := d.find(parent)
+	d.parent[v] = root
+	return root
+}
+
+func (d disjointSet[V]) union(a V, b V) bool {
+	rootA, rootB := d.find(a), d.find(b)
+
+	if rootA == rootB {
+		return false
+	}
+
+	if d.rank[rootA] < d.rank[rootB] {
+		rootA, rootB = rootB, rootA
+	}
+
+	d.parent[rootB] = rootA
+
+	if d.rank[rootA] == d.rank[rootB] {
+		d.rank[rootA]++
+	}
+
+	return true
+}
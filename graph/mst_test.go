@@ -0,0 +1,55 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMinimumSpanningTree(t *testing.T) {
+	g := NewUndirected[string]()
+	g.AddEdge("a", "b", 4)
+	g.AddEdge("a", "h", 8)
+	g.AddEdge("b", "c", 8)
+	g.AddEdge("b", "h", 11)
+	g.AddEdge("c", "d", 7)
+	g.AddEdge("c", "f", 4)
+	g.AddEdge("c", "i", 2)
+	g.AddEdge("d", "e", 9)
+	g.AddEdge("d", "f", 14)
+	g.AddEdge("e", "f", 10)
+	g.AddEdge("f", "g", 2)
+	g.AddEdge("g", "h", 1)
+	g.AddEdge("g", "i", 6)
+	g.AddEdge("h", "i", 7)
+
+	for name, mst := range map[string]func(*Graph[string]) ([]Edge[string], error){"Prim": Prim[string], "Kruskal": Kruskal[string]} {
+		tree, err := mst(g)
+
+		if err != nil || len(tree) != 8 || TotalWeight(tree) != 37 {
+			t.Errorf("%s: expected 8 edges weighing 37, got %d weighing %d (%v)", name, len(tree), TotalWeight(tree), err)
+		}
+	}
+
+	if _, err := Prim(NewDirected[int]()); err == nil {
+		t.Error("Expected an error for a directed graph")
+	}
+}
+
+func TestMinimumSpanningForestRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	g := NewUndirected[int]()
+
+	for range 400 {
+		g.AddEdge(rng.Intn(100), rng.Intn(100), int32(rng.Intn(50)))
+	}
+
+	g.AddEdge(200, 201, 3)
+
+	prim, _ := Prim(g)
+	kruskal, _ := Kruskal(g)
+
+	if len(prim) != len(kruskal) || TotalWeight(prim) != TotalWeight(kruskal) {
+		t.Errorf("Expected Prim and Kruskal to agree, got %d edges weighing %d and %d edges weighing %d",
+			len(prim), TotalWeight(prim), len(kruskal), TotalWeight(kruskal))
+	}
+}
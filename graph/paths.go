@@ -0,0 +1,154 @@
+package graph
+
+import (
+	"slices"
+
+	"github.com/XeniaPhe/xengods/pqueue"
+	"github.com/XeniaPhe/xengods/set"
+)
+
+// Distances are accumulated as int32, the priority type of pqueue, so the
+// total weight of any path must fit in an int32.
+type Paths[V comparable] struct {
+	source V
+	distances map[V]int32
+	previous map[V]V
+}
+
+func Dijkstra[V comparable](g *Graph[V], source V) (Paths[V], error) {
+	if !g.HasVertex(source) {
+		return Paths[V]{}, missingVertexError
+	}
+
+	if g.hasNegativeWeight() {
+		return Paths[V]{}, negativeWeightError
+	}
+
+	paths := Paths[V]{source, map[V]int32{source: 0}, make(map[V]V)}
+	frontier := pqueue.NewMinPQueue[V]()
+	visited := set.New[V](g.VertexCount())
+	frontier.Enqueue(source, 0)
+
+	for !frontier.IsEmpty() {
+		next, _ := frontier.Dequeue()
+		v := next.Value
+
+		if visited.Contains(v) {
+			continue
+		}
+
+		visited.Add(v)
+
+		for _, edge := range g.adjacency[v] {
+			distance := next.Priority + edge.Weight
+
+			if best, found := paths.distances[edge.To]; !found || distance < best {
+				paths.distances[edge.To] = distance
+				paths.previous[edge.To] = v
+				frontier.Enqueue(edge.To, distance)
+			}
+		}
+	}
+
+	return paths, nil
+}
+
+func (p Paths[V]) Source() V {
+	return p.source
+}
+
+func (p Paths[V]) Reachable(v V) bool {
+	_, found := p.distances[v]
+	return found
+}
+
+func (p Paths[V]) DistanceTo(v V) (int32, bool) {
+	distance, found := p.distances[v]
+	return distance, found
+}
+
+func (p Paths[V]) PathTo(v V) ([]V, error) {
+	if !p.Reachable(v) {
+		return nil, noPathError
+	}
+
+	return tracePath(p.previous, p.source, v), nil
+}
+
+// The heuristic must never overestimate the remaining distance and must be
+// consistent, otherwise the returned path is not guaranteed to be the shortest.
+// A nil heuristic makes AStar behave like Dijkstra.
+func AStar[V comparable](g *Graph[V], from V, to V, heuristic func(V) int32) ([]V, int32, error) {
+	if !g.HasVertex(from) || !g.HasVertex(to) {
+		return nil, 0, missingVertexError
+	}
+
+	if g.hasNegativeWeight() {
+		return nil, 0, negativeWeightError
+	}
+
+	if heuristic == nil {
+		heuristic = func(V) int32 { return 0 }
+	}
+
+	distances := map[V]int32{from: 0}
+	previous := make(map[V]V)
+	frontier := pqueue.NewMinPQueue[V]()
+	closed := set.New[V]()
+	frontier.Enqueue(from, heuristic(from))
+
+	for !frontier.IsEmpty() {
+		next, _ := frontier.Dequeue()
+		v := next.Value
+
+		if v == to {
+			return tracePath(previous, from, to), distances[to], nil
+		}
+
+		if closed.Contains(v) {
+			continue
+		}
+
+		closed.Add(v)
+
+		for _, edge := range g.adjacency[v] {
+			if closed.Contains(edge.To) {
+				continue
+			}
+
+			distance := distances[v] + edge.Weight
+
+			if best, found := distances[edge.To]; !found || distance < best {
+				distances[edge.To] = distance
+				previous[edge.To] = v
+				frontier.Enqueue(edge.To, distance+heuristic(edge.To))
+			}
+		}
+	}
+
+	return nil, 0, noPathError
+}
+
+func tracePath[V comparable](previous map[V]V, source V, target V) []V {
+	path := []V{target}
+
+	for v := target; v != source; {
+		v = previous[v]
+		path = append(path, v)
+	}
+
+	slices.Reverse(path)
+	return path
+}
+
+func (g *Graph[V]) hasNegativeWeight() bool {
+	for _, edges := range g.adjacency {
+		for _, edge := range edges {
+			if edge.Weight < 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,73 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDijkstra(t *testing.T) {
+	g := NewDirected[string]()
+	g.AddEdge("s", "a", 7)
+	g.AddEdge("s", "b", 2)
+	g.AddEdge("b", "a", 3)
+	g.AddEdge("a", "t", 1)
+	g.AddEdge("b", "t", 8)
+	g.AddVertex("x")
+
+	paths, err := Dijkstra(g, "s")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if distance, _ := paths.DistanceTo("t"); distance != 6 {
+		t.Errorf("Expected distance 6, got %d instead", distance)
+	}
+
+	if path, _ := paths.PathTo("t"); !slices.Equal(path, []string{"s", "b", "a", "t"}) {
+		t.Errorf("Expected [s b a t], got %v instead", path)
+	}
+
+	if path, _ := paths.PathTo("s"); !slices.Equal(path, []string{"s"}) {
+		t.Errorf("Expected [s], got %v instead", path)
+	}
+
+	if _, err := paths.PathTo("x"); err == nil || paths.Reachable("x") {
+		t.Error("Expected x to be unreachable")
+	}
+
+	if _, err := Dijkstra(g, "missing"); err == nil {
+		t.Error("Expected an error for a missing source")
+	}
+
+	g.AddEdge("x", "s", -1)
+
+	if _, err := Dijkstra(g, "s"); err == nil {
+		t.Error("Expected an error for a negative weight")
+	}
+}
+
+func TestAStar(t *testing.T) {
+	g := grid(10, 10)
+	goal := [2]int{9, 9}
+	manhattan := func(v [2]int) int32 { return int32(goal[0] - v[0] + goal[1] - v[1]) }
+
+	path, distance, err := AStar(g, [2]int{0, 0}, goal, manhattan)
+
+	if err != nil || distance != 18 || len(path) != 19 || path[0] != [2]int{0, 0} || path[18] != goal {
+		t.Errorf("Unexpected result: %v %d %v", path, distance, err)
+	}
+
+	_, plain, _ := AStar(g, [2]int{0, 0}, goal, nil)
+	paths, _ := Dijkstra(g, [2]int{0, 0})
+
+	if expected, _ := paths.DistanceTo(goal); plain != expected {
+		t.Errorf("Expected %d, got %d instead", expected, plain)
+	}
+
+	g.AddVertex([2]int{-1, -1})
+
+	if _, _, err := AStar(g, [2]int{0, 0}, [2]int{-1, -1}, manhattan); err == nil {
+		t.Error("Expected an error for an unreachable goal")
+	}
+}
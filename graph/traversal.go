@@ -0,0 +1,118 @@
+package graph
+
+import (
+	"iter"
+
+	"github.com/XeniaPhe/xengods/pqueue"
+	"github.com/XeniaPhe/xengods/set"
+	"github.com/XeniaPhe/xengods/stack"
+)
+
+func BFS[V comparable](g *Graph[V], start V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		if !g.HasVertex(start) {
+			return
+		}
+
+		queue := []V{start}
+		visited := set.Of(start)
+
+		for head := 0; head < len(queue); head++ {
+			v := queue[head]
+
+			if !yield(v) {
+				return
+			}
+
+			for _, edge := range g.adjacency[v] {
+				if !visited.Contains(edge.To) {
+					visited.Add(edge.To)
+					queue = append(queue, edge.To)
+				}
+			}
+		}
+	}
+}
+
+// Neighbors are visited in the order their edges were added, the same order a
+// recursive depth-first search would visit them.
+func DFS[V comparable](g *Graph[V], start V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		if !g.HasVertex(start) {
+			return
+		}
+
+		pending := stack.Of(start)
+		visited := set.New[V]()
+
+		for !pending.IsEmpty() {
+			v, _ := pending.Pop()
+
+			if visited.Contains(v) {
+				continue
+			}
+
+			visited.Add(v)
+
+			if !yield(v) {
+				return
+			}
+
+			edges := g.adjacency[v]
+
+			for i := len(edges) - 1; i >= 0; i-- {
+				if !visited.Contains(edges[i].To) {
+					pending.Push(edges[i].To)
+				}
+			}
+		}
+	}
+}
+
+// Of the vertices that are ready, the one added first comes first, so the result
+// is deterministic.
+func TopologicalSort[V comparable](g *Graph[V]) ([]V, error) {
+	if !g.directed {
+		return nil, notDirectedError
+	}
+
+	inDegree := make(map[V]int, len(g.vertices))
+	index := make(map[V]int32, len(g.vertices))
+
+	for i, v := range g.vertices {
+		index[v] = int32(i)
+
+		for _, edge := range g.adjacency[v] {
+			inDegree[edge.To]++
+		}
+	}
+
+	ready := pqueue.NewMinPQueue[V]()
+
+	for _, v := range g.vertices {
+		if inDegree[v] == 0 {
+			ready.Enqueue(v, index[v])
+		}
+	}
+
+	order := make([]V, 0, len(g.vertices))
+
+	for !ready.IsEmpty() {
+		next, _ := ready.Dequeue()
+		order = append(order, next.Value)
+
+		for _, edge := range g.adjacency[next.Value] {
+			inDegree[edge.To]--
+
+			if inDegree[edge.To] == 0 {
+				ready.Enqueue(edge.To, index[edge.To])
+			}
+		}
+	}
+
+	if len(order) < len(g.vertices) {
+		return nil, cycleError
+	}
+
+	return order, nil
+}
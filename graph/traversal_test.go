@@ -0,0 +1,73 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTraversal(t *testing.T) {
+	g := NewDirected[int]()
+	g.AddEdge(1, 2, 0)
+	g.AddEdge(1, 3, 0)
+	g.AddEdge(2, 4, 0)
+	g.AddEdge(3, 4, 0)
+	g.AddEdge(4, 5, 0)
+	g.AddEdge(5, 1, 0)
+
+	if order := slices.Collect(BFS(g, 1)); !slices.Equal(order, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("Expected BFS order [1 2 3 4 5], got %v instead", order)
+	}
+
+	if order := slices.Collect(DFS(g, 1)); !slices.Equal(order, []int{1, 2, 4, 5, 3}) {
+		t.Errorf("Expected DFS order [1 2 4 5 3], got %v instead", order)
+	}
+
+	for v := range DFS(g, 1) {
+		if v == 4 {
+			break
+		}
+	}
+
+	if order := slices.Collect(BFS(g, 9)); len(order) != 0 {
+		t.Errorf("Expected no vertices, got %v instead", order)
+	}
+}
+
+func TestTopologicalSort(t *testing.T) {
+	g := NewDirected[string]()
+	g.AddEdge("shirt", "tie", 0)
+	g.AddEdge("tie", "jacket", 0)
+	g.AddEdge("trousers", "shoes", 0)
+	g.AddEdge("trousers", "belt", 0)
+	g.AddEdge("belt", "jacket", 0)
+	g.AddEdge("socks", "shoes", 0)
+
+	order, err := TopologicalSort(g)
+	expected := []string{"shirt", "tie", "trousers", "belt", "jacket", "socks", "shoes"}
+
+	if err != nil || !slices.Equal(order, expected) {
+		t.Errorf("Expected %v, got %v (%v) instead", expected, order, err)
+	}
+
+	ties := NewDirected[string]()
+	ties.AddVertex("a")
+	ties.AddVertex("b")
+	ties.AddVertex("c")
+	ties.AddVertex("d")
+	ties.AddEdge("a", "d", 0)
+	ties.AddEdge("b", "c", 0)
+
+	if order, _ := TopologicalSort(ties); !slices.Equal(order, []string{"a", "b", "c", "d"}) {
+		t.Errorf("Expected %v, got %v instead", []string{"a", "b", "c", "d"}, order)
+	}
+
+	g.AddEdge("jacket", "shirt", 0)
+
+	if _, err := TopologicalSort(g); err == nil {
+		t.Error("Expected an error for a cyclic graph")
+	}
+
+	if _, err := TopologicalSort(NewUndirected[int]()); err == nil {
+		t.Error("Expected an error for an undirected graph")
+	}
+}
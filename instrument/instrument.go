@@ -0,0 +1,226 @@
+package instrument
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+type Op int
+
+const (
+	OpEnqueue Op = iota
+	OpDequeue
+	OpPeek
+	OpPush
+	OpPop
+	OpAdd
+	OpRemove
+	OpClear
+	OpUnion
+	OpIntersect
+	OpExcept
+	OpSymmetricExcept
+	opCount
+)
+
+func (o Op) String() string {
+	switch o {
+	case OpEnqueue:
+		return "Enqueue"
+	case OpDequeue:
+		return "Dequeue"
+	case OpPeek:
+		return "Peek"
+	case OpPush:
+		return "Push"
+	case OpPop:
+		return "Pop"
+	case OpAdd:
+		return "Add"
+	case OpRemove:
+		return "Remove"
+	case OpClear:
+		return "Clear"
+	case OpUnion:
+		return "Union"
+	case OpIntersect:
+		return "Intersect"
+	case OpExcept:
+		return "Except"
+	case OpSymmetricExcept:
+		return "SymmetricExcept"
+	default:
+		return "Unknown"
+	}
+}
+
+// Items is the number of elements the operation added or removed, and zero is
+// counted as one. CallLatency is how long the call took, not how long items
+// waited in the container.
+type Event struct {
+	Op          Op
+	Size        int
+	Items       int
+	CallLatency time.Duration
+}
+
+type Hook interface {
+	Observe(event Event)
+}
+
+type HookFunc func(event Event)
+
+func (f HookFunc) Observe(event Event) {
+	f(event)
+}
+
+func Observe(hook Hook, op Op, size int, start time.Time) {
+	ObserveItems(hook, op, 1, size, start)
+}
+
+func ObserveItems(hook Hook, op Op, items int, size int, start time.Time) {
+	hook.Observe(Event{op, size, items, time.Since(start)})
+}
+
+const DefaultSampleSize = 1024
+
+type Percentiles struct {
+	P50 time.Duration
+	P90 time.Duration
+	P99 time.Duration
+	Max time.Duration
+}
+
+type Report struct {
+	Counts        map[Op]uint64
+	Size          int
+	HighWaterMark int
+	CallLatency   map[Op]Percentiles
+}
+
+type samples struct {
+	values []time.Duration
+	next   int
+}
+
+type Stats struct {
+	mu            sync.Mutex
+	counts        [opCount]uint64
+	latencies     [opCount]samples
+	sampleSize    int
+	size          int
+	highWaterMark int
+}
+
+func NewStats(sampleSize ...int) *Stats {
+	size := DefaultSampleSize
+
+	if len(sampleSize) > 0 && sampleSize[0] > 0 {
+		size = sampleSize[0]
+	}
+
+	return &Stats{sampleSize: size}
+}
+
+func (s *Stats) Observe(event Event) {
+	if event.Op < 0 || event.Op >= opCount {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.counts[event.Op] += uint64(max(event.Items, 1))
+	s.size = event.Size
+	s.highWaterMark = max(s.highWaterMark, event.Size)
+
+	latencies := &s.latencies[event.Op]
+
+	if len(latencies.values) < s.sampleSize {
+		latencies.values = append(latencies.values, event.CallLatency)
+	} else {
+		latencies.values[latencies.next] = event.CallLatency
+		latencies.next = (latencies.next + 1) % s.sampleSize
+	}
+}
+
+func (s *Stats) Count(op Op) uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if op < 0 || op >= opCount {
+		return 0
+	}
+
+	return s.counts[op]
+}
+
+func (s *Stats) HighWaterMark() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.highWaterMark
+}
+
+func (s *Stats) Percentile(op Op, p float64) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if op < 0 || op >= opCount {
+		return 0
+	}
+
+	sorted := slices.Clone(s.latencies[op].values)
+	slices.Sort(sorted)
+	return percentile(sorted, p)
+}
+
+func (s *Stats) Report() Report {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	report := Report{
+		Counts:        make(map[Op]uint64),
+		Size:          s.size,
+		HighWaterMark: s.highWaterMark,
+		CallLatency:   make(map[Op]Percentiles),
+	}
+
+	for op := range opCount {
+		if s.counts[op] == 0 {
+			continue
+		}
+
+		sorted := slices.Clone(s.latencies[op].values)
+		slices.Sort(sorted)
+
+		report.Counts[op] = s.counts[op]
+		report.CallLatency[op] = Percentiles{
+			P50: percentile(sorted, 50),
+			P90: percentile(sorted, 90),
+			P99: percentile(sorted, 99),
+			Max: percentile(sorted, 100),
+		}
+	}
+
+	return report
+}
+
+func (s *Stats) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.counts = [opCount]uint64{}
+	s.latencies = [opCount]samples{}
+	s.size = 0
+	s.highWaterMark = 0
+}
+
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := int(p/100*float64(len(sorted)) + 0.5)
+	return sorted[min(max(rank-1, 0), len(sorted)-1)]
+}
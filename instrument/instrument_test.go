@@ -0,0 +1,90 @@
+package instrument
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStatsCountsAndHighWaterMark(t *testing.T) {
+	stats := NewStats()
+
+	for i := range 10 {
+		stats.Observe(Event{OpEnqueue, i + 1, 1, time.Microsecond})
+	}
+
+	for i := range 4 {
+		stats.Observe(Event{OpDequeue, 9 - i, 1, time.Microsecond})
+	}
+
+	stats.Observe(Event{Op(100), 1000, 1, 0})
+
+	if stats.Count(OpEnqueue) != 10 || stats.Count(OpDequeue) != 4 || stats.Count(OpPush) != 0 {
+		t.Errorf("Expected counts 10, 4 and 0, got %d, %d and %d instead",
+			stats.Count(OpEnqueue), stats.Count(OpDequeue), stats.Count(OpPush))
+	}
+
+	if stats.HighWaterMark() != 10 {
+		t.Errorf("Expected high-water mark 10, got %d instead", stats.HighWaterMark())
+	}
+
+	report := stats.Report()
+
+	if report.Size != 6 {
+		t.Errorf("Expected size 6, got %d instead", report.Size)
+	}
+
+	if len(report.Counts) != 2 || report.Counts[OpEnqueue] != 10 {
+		t.Errorf("Unexpected counts: %v", report.Counts)
+	}
+
+	stats.Reset()
+
+	if stats.Count(OpEnqueue) != 0 || stats.HighWaterMark() != 0 || len(stats.Report().Counts) != 0 {
+		t.Error("Expected the stats to be reset")
+	}
+}
+
+func TestStatsPercentiles(t *testing.T) {
+	stats := NewStats(100)
+
+	for i := 1; i <= 100; i++ {
+		stats.Observe(Event{OpPush, 0, 1, time.Duration(i) * time.Millisecond})
+	}
+
+	if p := stats.Percentile(OpPush, 50); p != 50*time.Millisecond {
+		t.Errorf("Expected 50ms, got %v instead", p)
+	}
+
+	latency := stats.Report().CallLatency[OpPush]
+
+	if latency.P90 != 90*time.Millisecond || latency.P99 != 99*time.Millisecond || latency.Max != 100*time.Millisecond {
+		t.Errorf("Unexpected percentiles: %+v", latency)
+	}
+
+	for range 100 {
+		stats.Observe(Event{OpPush, 0, 1, time.Second})
+	}
+
+	if p := stats.Percentile(OpPush, 50); p != time.Second {
+		t.Errorf("Expected old samples to be replaced, got %v instead", p)
+	}
+
+	if p := stats.Percentile(OpPop, 50); p != 0 {
+		t.Errorf("Expected 0, got %v instead", p)
+	}
+}
+
+func TestHookFunc(t *testing.T) {
+	var events []Event
+	hook := HookFunc(func(event Event) { events = append(events, event) })
+
+	Observe(hook, OpAdd, 3, time.Now())
+
+	if len(events) != 1 || events[0].Op != OpAdd || events[0].Size != 3 || events[0].CallLatency < 0 {
+		t.Errorf("Unexpected events: %v", events)
+	}
+
+	if OpAdd.String() != "Add" || Op(100).String() != "Unknown" {
+		t.Errorf("Unexpected op names: %s and %s", OpAdd, Op(100))
+	}
+}
@@ -0,0 +1,375 @@
+package pheap
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/XeniaPhe/xengods/pqueue"
+)
+
+var emptyHeapError error
+var detachedNodeError error
+var mismatchedHeapsError error
+var foreignNodeError error
+
+func init() {
+	emptyHeapError = errors.New("empty pairing heap")
+	detachedNodeError = errors.New("node is not in the pairing heap")
+	mismatchedHeapsError = errors.New("cannot meld a min pairing heap with a max pairing heap")
+	foreignNodeError = errors.New("node belongs to a different pairing heap")
+}
+
+func minCompare(lhs int32, rhs int32) bool { return lhs < rhs }
+func maxCompare(lhs int32, rhs int32) bool { return lhs > rhs }
+
+// Nodes point at their heap's owner cell rather than at the heap itself, so
+// Meld can hand every node of the other heap over in O(1) by forwarding its
+// cell to the receiver's.
+type owner struct {
+	forward *owner
+}
+
+func (o *owner) resolve() *owner {
+	root := o
+
+	for root.forward != nil {
+		root = root.forward
+	}
+
+	for o != root {
+		o, o.forward = o.forward, root
+	}
+
+	return root
+}
+
+type Node[T any] struct {
+	Value    T
+	priority int32
+	child    *Node[T]
+	sibling  *Node[T]
+	prev     *Node[T]
+	owner    *owner
+}
+
+func (n *Node[T]) Priority() int32 {
+	return n.priority
+}
+
+func (n *Node[T]) Item() pqueue.Item[T] {
+	return pqueue.Item[T]{Value: n.Value, Priority: n.priority}
+}
+
+type PHeap[T any] struct {
+	root    *Node[T]
+	size    int
+	compare func(int32, int32) bool
+	owner   *owner
+}
+
+func NewMinPHeap[T any]() PHeap[T] {
+	return PHeap[T]{compare: minCompare, owner: &owner{}}
+}
+
+func NewMaxPHeap[T any]() PHeap[T] {
+	return PHeap[T]{compare: maxCompare, owner: &owner{}}
+}
+
+func NewPHeap[T any](min bool) PHeap[T] {
+	if min {
+		return NewMinPHeap[T]()
+	}
+
+	return NewMaxPHeap[T]()
+}
+
+func (h PHeap[T]) IsInitialized() bool {
+	return h.compare != nil
+}
+
+func (h *PHeap[T]) InitializeIfNot(min bool) {
+	if h.compare != nil {
+		return
+	}
+
+	if min {
+		h.compare = minCompare
+	} else {
+		h.compare = maxCompare
+	}
+}
+
+func (h PHeap[T]) Clone() PHeap[T] {
+	clone := PHeap[T]{compare: h.compare}
+
+	for _, item := range h.items() {
+		clone.Enqueue(item.Value, item.Priority)
+	}
+
+	return clone
+}
+
+func (h *PHeap[T]) Enqueue(value T, priority int32) *Node[T] {
+	node := &Node[T]{Value: value, priority: priority, owner: h.ownerCell()}
+	h.root = h.link(h.root, node)
+	h.size++
+	return node
+}
+
+func (h *PHeap[T]) Dequeue() (pqueue.Item[T], error) {
+	if h.root == nil {
+		return pqueue.Item[T]{}, emptyHeapError
+	}
+
+	root := h.root
+	h.root = h.mergePairs(root.child)
+	h.size--
+	root.detach()
+	return root.Item(), nil
+}
+
+func (h PHeap[T]) Peek() (pqueue.Item[T], error) {
+	if h.root == nil {
+		return pqueue.Item[T]{}, emptyHeapError
+	}
+
+	return h.root.Item(), nil
+}
+
+func (h *PHeap[T]) Meld(other *PHeap[T]) error {
+	if h == other || other.root == nil {
+		return nil
+	}
+
+	h.InitializeIfNot(other.compare(0, 1))
+
+	if h.compare(0, 1) != other.compare(0, 1) {
+		return mismatchedHeapsError
+	}
+
+	h.root = h.link(h.root, other.root)
+	h.size += other.size
+	other.ownerCell().forward = h.ownerCell()
+	other.owner = &owner{}
+	other.root = nil
+	other.size = 0
+	return nil
+}
+
+func (h *PHeap[T]) UpdatePriority(node *Node[T], priority int32) error {
+	if err := h.checkOwner(node); err != nil {
+		return err
+	}
+
+	if !h.compare(node.priority, priority) {
+		node.priority = priority
+
+		if node != h.root {
+			h.cut(node)
+			h.root = h.link(h.root, node)
+		}
+
+		return nil
+	}
+
+	h.remove(node)
+	node.priority = priority
+	node.owner = h.owner
+	h.root = h.link(h.root, node)
+	h.size++
+	return nil
+}
+
+func (h *PHeap[T]) Remove(node *Node[T]) error {
+	if err := h.checkOwner(node); err != nil {
+		return err
+	}
+
+	h.remove(node)
+	return nil
+}
+
+func (h *PHeap[T]) Clear() {
+	for _, node := range h.nodes() {
+		node.detach()
+	}
+
+	h.root = nil
+	h.size = 0
+}
+
+func (h PHeap[T]) IsEmpty() bool {
+	return h.size == 0
+}
+
+func (h PHeap[T]) Size() int {
+	return h.size
+}
+
+func (h PHeap[T]) String() string {
+	var builder strings.Builder
+	if h.compare(0, 1) {
+		builder.WriteString("Min")
+	} else {
+		builder.WriteString("Max")
+	}
+
+	builder.WriteString("PHeap[")
+
+	clone := h.Clone()
+	first := true
+
+	for !clone.IsEmpty() {
+		if !first {
+			builder.WriteString(", ")
+		}
+
+		item, err := clone.Dequeue()
+
+		if err != nil {
+			panic(err)
+		}
+
+		builder.WriteString(fmt.Sprintf("(%v:%d)", item.Value, item.Priority))
+		first = false
+	}
+
+	builder.WriteString("]")
+	return builder.String()
+}
+
+func (n *Node[T]) detach() {
+	n.child = nil
+	n.sibling = nil
+	n.prev = nil
+	n.owner = nil
+}
+
+func (h *PHeap[T]) ownerCell() *owner {
+	if h.owner == nil {
+		h.owner = &owner{}
+	}
+
+	return h.owner
+}
+
+func (h *PHeap[T]) checkOwner(node *Node[T]) error {
+	if node.owner == nil {
+		return detachedNodeError
+	}
+
+	if h.owner == nil || node.owner.resolve() != h.owner {
+		return foreignNodeError
+	}
+
+	return nil
+}
+
+func (h *PHeap[T]) remove(node *Node[T]) {
+	if node == h.root {
+		h.root = h.mergePairs(node.child)
+	} else {
+		h.cut(node)
+		h.root = h.link(h.root, h.mergePairs(node.child))
+	}
+
+	h.size--
+	node.detach()
+}
+
+func (h PHeap[T]) link(lhs *Node[T], rhs *Node[T]) *Node[T] {
+	if lhs == nil {
+		return rhs
+	}
+
+	if rhs == nil {
+		return lhs
+	}
+
+	if h.compare(rhs.priority, lhs.priority) {
+		lhs, rhs = rhs, lhs
+	}
+
+	rhs.prev = lhs
+	rhs.sibling = lhs.child
+
+	if lhs.child != nil {
+		lhs.child.prev = rhs
+	}
+
+	lhs.child = rhs
+	lhs.sibling = nil
+	lhs.prev = nil
+	return lhs
+}
+
+func (h PHeap[T]) cut(node *Node[T]) {
+	if node.prev.child == node {
+		node.prev.child = node.sibling
+	} else {
+		node.prev.sibling = node.sibling
+	}
+
+	if node.sibling != nil {
+		node.sibling.prev = node.prev
+	}
+
+	node.prev = nil
+	node.sibling = nil
+}
+
+func (h PHeap[T]) mergePairs(first *Node[T]) *Node[T] {
+	var pairs []*Node[T]
+
+	for first != nil {
+		second := first.sibling
+
+		if second == nil {
+			first.prev = nil
+			pairs = append(pairs, first)
+			break
+		}
+
+		next := second.sibling
+		first.sibling, first.prev = nil, nil
+		second.sibling, second.prev = nil, nil
+		pairs = append(pairs, h.link(first, second))
+		first = next
+	}
+
+	var merged *Node[T]
+
+	for i := len(pairs) - 1; i >= 0; i-- {
+		merged = h.link(pairs[i], merged)
+	}
+
+	return merged
+}
+
+func (h PHeap[T]) nodes() []*Node[T] {
+	nodes := make([]*Node[T], 0, h.size)
+
+	if h.root != nil {
+		nodes = append(nodes, h.root)
+	}
+
+	for i := 0; i < len(nodes); i++ {
+		for child := nodes[i].child; child != nil; child = child.sibling {
+			nodes = append(nodes, child)
+		}
+	}
+
+	return nodes
+}
+
+func (h PHeap[T]) items() []pqueue.Item[T] {
+	nodes := h.nodes()
+	items := make([]pqueue.Item[T], len(nodes))
+
+	for i, node := range nodes {
+		items[i] = node.Item()
+	}
+
+	return items
+}
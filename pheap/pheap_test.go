@@ -0,0 +1,328 @@
+package pheap
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func drainPriorities[T any](h *PHeap[T]) []int32 {
+	priorities := make([]int32, 0, h.Size())
+
+	for !h.IsEmpty() {
+		item, _ := h.Dequeue()
+		priorities = append(priorities, item.Priority)
+	}
+
+	return priorities
+}
+
+func TestPHeapConstructors(t *testing.T) {
+	minph := NewMinPHeap[int]()
+
+	if !minph.IsInitialized() || !minph.IsEmpty() {
+		t.Error("Expected an empty initialized heap")
+	}
+
+	maxph := NewPHeap[int](false)
+
+	if !maxph.IsInitialized() || maxph.Size() != 0 {
+		t.Error("Expected an empty initialized heap")
+	}
+
+	var uninitialized PHeap[int]
+
+	if uninitialized.IsInitialized() {
+		t.Error("Expected false, got true instead")
+	}
+
+	uninitialized.InitializeIfNot(true)
+
+	if !uninitialized.IsInitialized() {
+		t.Error("Expected true, got false instead")
+	}
+}
+
+func TestPHeapEnqueueDequeuePeek(t *testing.T) {
+	h := NewMinPHeap[string]()
+
+	_, err := h.Dequeue()
+
+	if err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	_, err = h.Peek()
+
+	if err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	h.Enqueue("c", 3)
+	h.Enqueue("a", 1)
+	h.Enqueue("d", 4)
+	h.Enqueue("b", 2)
+
+	item, err := h.Peek()
+
+	if err != nil {
+		t.Errorf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	if item.Value != "a" || item.Priority != 1 {
+		t.Errorf("Expected (a:1), got (%s:%d) instead", item.Value, item.Priority)
+	}
+
+	if h.String() != "MinPHeap[(a:1), (b:2), (c:3), (d:4)]" {
+		t.Errorf("Expected 'MinPHeap[(a:1), (b:2), (c:3), (d:4)]', got '%s' instead", h.String())
+	}
+
+	if h.Size() != 4 {
+		t.Errorf("Expected size 4, got %d instead", h.Size())
+	}
+
+	for _, expected := range []string{"a", "b", "c", "d"} {
+		item, err := h.Dequeue()
+
+		if err != nil {
+			t.Errorf("Expected no error, got '%s' instead", err.Error())
+		}
+
+		if item.Value != expected {
+			t.Errorf("Expected value %s, got %s instead", expected, item.Value)
+		}
+	}
+
+	maxh := NewMaxPHeap[int]()
+
+	for i := range 10 {
+		maxh.Enqueue(i, int32(i))
+	}
+
+	priorities := drainPriorities(&maxh)
+
+	if !slices.Equal(priorities, []int32{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}) {
+		t.Errorf("Expected descending priorities, got %v instead", priorities)
+	}
+}
+
+func TestPHeapMeld(t *testing.T) {
+	h1 := NewMinPHeap[int]()
+	h2 := NewMinPHeap[int]()
+
+	for i := range 5 {
+		h1.Enqueue(i, int32(i*2))
+		h2.Enqueue(i, int32(i*2+1))
+	}
+
+	if err := h1.Meld(&h2); err != nil {
+		t.Errorf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	if h1.Size() != 10 {
+		t.Errorf("Expected size 10, got %d instead", h1.Size())
+	}
+
+	if !h2.IsEmpty() {
+		t.Errorf("Expected size 0, got %d instead", h2.Size())
+	}
+
+	priorities := drainPriorities(&h1)
+
+	if !slices.Equal(priorities, []int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Errorf("Expected ascending priorities, got %v instead", priorities)
+	}
+
+	maxh := NewMaxPHeap[int]()
+	maxh.Enqueue(1, 1)
+
+	if err := h1.Meld(&maxh); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	var empty PHeap[int]
+
+	if err := empty.Meld(&maxh); err != nil {
+		t.Errorf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	if empty.Size() != 1 || empty.String() != "MaxPHeap[(1:1)]" {
+		t.Errorf("Expected 'MaxPHeap[(1:1)]', got '%s' instead", empty.String())
+	}
+}
+
+func TestPHeapUpdatePriorityRemove(t *testing.T) {
+	h := NewMinPHeap[string]()
+	a := h.Enqueue("a", 10)
+	b := h.Enqueue("b", 20)
+	c := h.Enqueue("c", 30)
+	d := h.Enqueue("d", 40)
+
+	if err := h.UpdatePriority(d, 5); err != nil {
+		t.Errorf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	item, _ := h.Peek()
+
+	if item.Value != "d" || d.Priority() != 5 {
+		t.Errorf("Expected (d:5), got (%s:%d) instead", item.Value, item.Priority)
+	}
+
+	if err := h.UpdatePriority(d, 50); err != nil {
+		t.Errorf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	item, _ = h.Peek()
+
+	if item.Value != "a" {
+		t.Errorf("Expected value a, got %s instead", item.Value)
+	}
+
+	if err := h.Remove(b); err != nil {
+		t.Errorf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	if err := h.Remove(b); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	if h.String() != "MinPHeap[(a:10), (c:30), (d:50)]" {
+		t.Errorf("Expected 'MinPHeap[(a:10), (c:30), (d:50)]', got '%s' instead", h.String())
+	}
+
+	h.Dequeue()
+
+	if err := h.UpdatePriority(a, 1); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	h.Clear()
+
+	if !h.IsEmpty() {
+		t.Errorf("Expected size 0, got %d instead", h.Size())
+	}
+
+	if err := h.Remove(c); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+}
+
+func TestPHeapForeignNodes(t *testing.T) {
+	a := NewMinPHeap[string]()
+	b := NewMinPHeap[string]()
+	a.Enqueue("a", 10)
+	foreign := b.Enqueue("b", 20)
+
+	if err := a.Remove(foreign); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	if err := a.UpdatePriority(foreign, 1); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	if a.Size() != 1 || b.Size() != 1 {
+		t.Errorf("Expected sizes 1 and 1, got %d and %d instead", a.Size(), b.Size())
+	}
+
+	c := NewMinPHeap[string]()
+	c.Enqueue("c", 30)
+
+	if err := b.Meld(&c); err != nil {
+		t.Errorf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	b.Enqueue("d", 40)
+
+	if err := a.Meld(&b); err != nil {
+		t.Errorf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	if err := b.UpdatePriority(foreign, 0); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	if err := c.Remove(foreign); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	if a.Size() != 4 || b.Size() != 0 || !b.IsEmpty() {
+		t.Errorf("Expected sizes 4 and 0, got %d and %d instead", a.Size(), b.Size())
+	}
+
+	if err := a.UpdatePriority(foreign, 0); err != nil {
+		t.Errorf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	late := b.Enqueue("e", 50)
+
+	if err := a.Remove(late); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	if a.String() != "MinPHeap[(b:0), (a:10), (c:30), (d:40)]" {
+		t.Errorf("Expected 'MinPHeap[(b:0), (a:10), (c:30), (d:40)]', got '%s' instead", a.String())
+	}
+}
+
+func TestPHeapRandomized(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	h := NewMaxPHeap[int]()
+	nodes := make(map[int]*Node[int])
+	reference := make(map[int]int32)
+
+	for i := range 3000 {
+		switch op := rng.Intn(5); {
+		case op <= 1 || len(reference) == 0:
+			priority := int32(rng.Intn(1000))
+			nodes[i] = h.Enqueue(i, priority)
+			reference[i] = priority
+		case op == 2:
+			item, err := h.Dequeue()
+
+			if err != nil {
+				t.Fatalf("Expected no error, got '%s' instead", err.Error())
+			}
+
+			for _, priority := range reference {
+				if priority > item.Priority {
+					t.Fatalf("Dequeued priority %d while %d was still enqueued", item.Priority, priority)
+				}
+			}
+
+			delete(reference, item.Value)
+			delete(nodes, item.Value)
+		case op == 3:
+			for key, node := range nodes {
+				priority := int32(rng.Intn(1000))
+
+				if err := h.UpdatePriority(node, priority); err != nil {
+					t.Fatalf("Expected no error, got '%s' instead", err.Error())
+				}
+
+				reference[key] = priority
+				break
+			}
+		default:
+			for key, node := range nodes {
+				if err := h.Remove(node); err != nil {
+					t.Fatalf("Expected no error, got '%s' instead", err.Error())
+				}
+
+				delete(reference, key)
+				delete(nodes, key)
+				break
+			}
+		}
+
+		if h.Size() != len(reference) {
+			t.Fatalf("Expected size %d, got %d instead", len(reference), h.Size())
+		}
+	}
+
+	priorities := drainPriorities(&h)
+
+	if !slices.IsSortedFunc(priorities, func(lhs int32, rhs int32) int { return int(rhs - lhs) }) {
+		t.Error("Expected priorities in descending order")
+	}
+}
@@ -0,0 +1,219 @@
+package pqueue
+
+import (
+	"math"
+	"time"
+)
+
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+type Aging interface {
+	Boost(priority int32, waited time.Duration) int32
+}
+
+type AgingFunc func(priority int32, waited time.Duration) int32
+
+func (f AgingFunc) Boost(priority int32, waited time.Duration) int32 {
+	return f(priority, waited)
+}
+
+// Linear accrues Step priority for every Every waited, continuously rather than
+// in whole steps. Its boost is the same for equal waits regardless of the
+// priority, which lets the queue order items by a key that never changes.
+type Linear struct {
+	Step  int32
+	Every time.Duration
+}
+
+func LinearAging(step int32, every time.Duration) Linear {
+	return Linear{step, every}
+}
+
+func (l Linear) Boost(_ int32, waited time.Duration) int32 {
+	return saturateFloat(math.Floor(l.rate() * float64(waited)))
+}
+
+func (l Linear) rate() float64 {
+	if l.Every <= 0 {
+		return 0
+	}
+
+	return float64(l.Step) / float64(l.Every)
+}
+
+var NoAging = LinearAging(0, 0)
+
+type agingEntry[T any] struct {
+	value      T
+	priority   int32
+	enqueuedAt time.Time
+	seq        uint64
+	key        float64
+}
+
+type AgedItem[T any] struct {
+	Item[T]
+	EffectivePriority int32
+	Waited            time.Duration
+}
+
+// With Linear aging every operation is O(log n). Any other Aging makes Peek and
+// Dequeue recompute every effective priority and rebuild the heap, which is O(n).
+type AgingPQueue[T any] struct {
+	heap   []agingEntry[T]
+	aging  Aging
+	linear bool
+	rate   float64
+	clock  Clock
+	min    bool
+	epoch  time.Time
+	seq    uint64
+}
+
+func NewAgingPQueue[T any](min bool, aging Aging, clock ...Clock) AgingPQueue[T] {
+	var c Clock = systemClock{}
+
+	if len(clock) > 0 && clock[0] != nil {
+		c = clock[0]
+	}
+
+	if aging == nil {
+		aging = NoAging
+	}
+
+	linear, isLinear := aging.(Linear)
+	return AgingPQueue[T]{nil, aging, isLinear, linear.rate(), c, min, c.Now(), 0}
+}
+
+func (a *AgingPQueue[T]) Enqueue(value T, priority int32) {
+	now := a.clock.Now()
+	entry := agingEntry[T]{value, priority, now, a.seq, a.sign() * float64(priority)}
+	a.seq++
+
+	if a.linear {
+		entry.key += a.rate * float64(now.Sub(a.epoch))
+	}
+
+	a.heap = append(a.heap, entry)
+	siftUp(a.heap, len(a.heap)-1, DefaultArity, a.less)
+}
+
+func (a *AgingPQueue[T]) Dequeue() (AgedItem[T], error) {
+	if len(a.heap) == 0 {
+		return AgedItem[T]{}, emptyPQError
+	}
+
+	now := a.clock.Now()
+	a.refresh(now)
+	last := len(a.heap) - 1
+	top := a.heap[0]
+	a.heap[0] = a.heap[last]
+	a.heap[last] = agingEntry[T]{}
+	a.heap = a.heap[:last]
+	siftDown(a.heap, 0, DefaultArity, a.less)
+	return a.aged(top, now), nil
+}
+
+func (a *AgingPQueue[T]) Peek() (AgedItem[T], error) {
+	if len(a.heap) == 0 {
+		return AgedItem[T]{}, emptyPQError
+	}
+
+	now := a.clock.Now()
+	a.refresh(now)
+	return a.aged(a.heap[0], now), nil
+}
+
+func (a AgingPQueue[T]) Metrics() []AgedItem[T] {
+	now := a.clock.Now()
+	metrics := make([]AgedItem[T], len(a.heap))
+
+	for i, entry := range a.heap {
+		metrics[i] = a.aged(entry, now)
+	}
+
+	return metrics
+}
+
+func (a AgingPQueue[T]) OldestWait() time.Duration {
+	now := a.clock.Now()
+	var oldest time.Duration
+
+	for _, entry := range a.heap {
+		oldest = max(oldest, now.Sub(entry.enqueuedAt))
+	}
+
+	return oldest
+}
+
+func (a *AgingPQueue[T]) Clear() {
+	clear(a.heap)
+	a.heap = a.heap[:0]
+}
+
+func (a AgingPQueue[T]) IsEmpty() bool {
+	return len(a.heap) == 0
+}
+
+func (a AgingPQueue[T]) Size() int {
+	return len(a.heap)
+}
+
+func (a AgingPQueue[T]) refresh(now time.Time) {
+	if a.linear {
+		return
+	}
+
+	for i := range a.heap {
+		a.heap[i].key = a.sign() * float64(a.aged(a.heap[i], now).EffectivePriority)
+	}
+
+	heapify(a.heap, DefaultArity, a.less)
+}
+
+func (a AgingPQueue[T]) aged(entry agingEntry[T], now time.Time) AgedItem[T] {
+	waited := now.Sub(entry.enqueuedAt)
+	boost := int64(a.aging.Boost(entry.priority, waited))
+
+	if !a.min {
+		boost = -boost
+	}
+
+	return AgedItem[T]{
+		Item:              Item[T]{entry.value, entry.priority},
+		EffectivePriority: saturate(int64(entry.priority) - boost),
+		Waited:            waited,
+	}
+}
+
+func (a AgingPQueue[T]) sign() float64 {
+	if a.min {
+		return 1
+	}
+
+	return -1
+}
+
+func (a AgingPQueue[T]) less(lhs agingEntry[T], rhs agingEntry[T]) bool {
+	if lhs.key != rhs.key {
+		return lhs.key < rhs.key
+	}
+
+	return lhs.seq < rhs.seq
+}
+
+func saturate(value int64) int32 {
+	return int32(min(max(value, math.MinInt32), math.MaxInt32))
+}
+
+func saturateFloat(value float64) int32 {
+	return int32(min(max(value, math.MinInt32), math.MaxInt32))
+}
@@ -0,0 +1,227 @@
+package pqueue
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+type manualClock struct {
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	return c.now
+}
+
+func (c *manualClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func TestAgingPreventsStarvation(t *testing.T) {
+	clock := &manualClock{time.Unix(0, 0)}
+	aq := NewAgingPQueue[string](false, LinearAging(1, time.Second), clock)
+
+	aq.Enqueue("low", 1)
+	clock.Advance(5 * time.Second)
+	aq.Enqueue("high", 5)
+
+	item, err := aq.Dequeue()
+
+	if err != nil {
+		t.Errorf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	if item.Value != "low" {
+		t.Errorf("Expected value low, got %s instead", item.Value)
+	}
+
+	if item.Priority != 1 || item.EffectivePriority != 6 {
+		t.Errorf("Expected priorities 1 and 6, got %d and %d instead", item.Priority, item.EffectivePriority)
+	}
+
+	if item.Waited != 5*time.Second {
+		t.Errorf("Expected wait 5s, got %v instead", item.Waited)
+	}
+
+	item, _ = aq.Dequeue()
+
+	if item.Value != "high" || item.Waited != 0 {
+		t.Errorf("Expected high with no wait, got %s with %v instead", item.Value, item.Waited)
+	}
+
+	if _, err := aq.Dequeue(); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	if _, err := aq.Peek(); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+}
+
+func TestAgingMinQueue(t *testing.T) {
+	clock := &manualClock{time.Unix(0, 0)}
+	aq := NewAgingPQueue[string](true, LinearAging(2, time.Minute), clock)
+
+	aq.Enqueue("old", 10)
+	clock.Advance(3 * time.Minute)
+	aq.Enqueue("new", 5)
+
+	peeked, _ := aq.Peek()
+
+	if peeked.Value != "old" || peeked.EffectivePriority != 4 {
+		t.Errorf("Expected old with priority 4, got %s with %d instead", peeked.Value, peeked.EffectivePriority)
+	}
+
+	if aq.Size() != 2 {
+		t.Errorf("Expected size 2, got %d instead", aq.Size())
+	}
+
+	item, _ := aq.Dequeue()
+
+	if item != peeked {
+		t.Errorf("Expected %v, got %v instead", peeked, item)
+	}
+}
+
+func TestAgingMetrics(t *testing.T) {
+	clock := &manualClock{time.Unix(100, 0)}
+	aq := NewAgingPQueue[int](false, nil, clock)
+
+	for i := range 3 {
+		aq.Enqueue(i, int32(i))
+		clock.Advance(time.Second)
+	}
+
+	if aq.OldestWait() != 3*time.Second {
+		t.Errorf("Expected 3s, got %v instead", aq.OldestWait())
+	}
+
+	metrics := aq.Metrics()
+
+	if len(metrics) != 3 {
+		t.Fatalf("Expected 3 metrics, got %d instead", len(metrics))
+	}
+
+	for _, metric := range metrics {
+		expected := time.Duration(3-metric.Value) * time.Second
+
+		if metric.Waited != expected {
+			t.Errorf("Expected wait %v for %d, got %v instead", expected, metric.Value, metric.Waited)
+		}
+
+		if metric.EffectivePriority != metric.Priority {
+			t.Errorf("Expected no aging, got %d instead of %d", metric.EffectivePriority, metric.Priority)
+		}
+	}
+
+	item, _ := aq.Dequeue()
+
+	if item.Value != 2 {
+		t.Errorf("Expected value 2, got %d instead", item.Value)
+	}
+
+	aq.Clear()
+
+	if !aq.IsEmpty() || aq.OldestWait() != 0 {
+		t.Error("Expected an empty queue")
+	}
+}
+
+func TestAgingSaturation(t *testing.T) {
+	clock := &manualClock{time.Unix(0, 0)}
+	aq := NewAgingPQueue[int](false, LinearAging(math.MaxInt32, time.Nanosecond), clock)
+
+	aq.Enqueue(1, math.MaxInt32-1)
+	clock.Advance(time.Hour)
+
+	item, _ := aq.Peek()
+
+	if item.EffectivePriority != math.MaxInt32 {
+		t.Errorf("Expected %d, got %d instead", int32(math.MaxInt32), item.EffectivePriority)
+	}
+
+	if LinearAging(1, 0).Boost(0, time.Hour) != 0 {
+		t.Error("Expected no boost for a non-positive interval")
+	}
+}
+
+func TestAgingMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	quadratic := AgingFunc(func(priority int32, waited time.Duration) int32 {
+		return saturate(int64(waited/time.Second) * int64(waited/time.Second))
+	})
+
+	for _, aging := range []Aging{LinearAging(3, time.Second), quadratic} {
+		for _, min := range []bool{true, false} {
+			clock := &manualClock{time.Unix(0, 0)}
+			aq := NewAgingPQueue[int](min, aging, clock)
+			compare := NewPQueue[int](min).compare
+			dequeued := 0
+
+			for i := range 500 {
+				clock.Advance(time.Duration(rng.Intn(2000)) * time.Millisecond)
+
+				if rng.Intn(3) > 0 || aq.IsEmpty() {
+					aq.Enqueue(i, int32(rng.Intn(200)))
+					continue
+				}
+
+				peeked, _ := aq.Peek()
+				item, _ := aq.Dequeue()
+
+				if peeked != item {
+					t.Fatalf("Expected Peek and Dequeue to agree, got %v and %v", peeked, item)
+				}
+
+				for _, other := range aq.Metrics() {
+					if compare(other.EffectivePriority, item.EffectivePriority) {
+						t.Fatalf("Dequeued %v while %v was pending", item, other)
+					}
+				}
+
+				dequeued++
+			}
+
+			if dequeued == 0 {
+				t.Fatal("Expected some items to be dequeued")
+			}
+		}
+	}
+}
+
+func TestAgingTiesAreFIFO(t *testing.T) {
+	clock := &manualClock{time.Unix(0, 0)}
+	aq := NewAgingPQueue[string](false, LinearAging(1, time.Second), clock)
+
+	aq.Enqueue("first", 5)
+	aq.Enqueue("second", 5)
+	aq.Enqueue("third", 5)
+
+	for _, expected := range []string{"first", "second", "third"} {
+		peeked, _ := aq.Peek()
+		item, _ := aq.Dequeue()
+
+		if peeked.Value != expected || item.Value != expected {
+			t.Errorf("Expected %s, got %s and %s instead", expected, peeked.Value, item.Value)
+		}
+	}
+}
+
+func BenchmarkAgingDrain(b *testing.B) {
+	clock := &manualClock{time.Unix(0, 0)}
+
+	for range b.N {
+		aq := NewAgingPQueue[int](false, LinearAging(1, time.Second), clock)
+
+		for i := range 1 << 14 {
+			clock.Advance(time.Millisecond)
+			aq.Enqueue(i, int32(i%100))
+		}
+
+		for !aq.IsEmpty() {
+			aq.Dequeue()
+		}
+	}
+}
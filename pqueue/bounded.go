@@ -0,0 +1,105 @@
+package pqueue
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type BoundedPQueue[T any] struct {
+	pq    PQueue[T]
+	limit int
+}
+
+func NewTopK[T any](k int) BoundedPQueue[T] {
+	return BoundedPQueue[T]{NewMinPQueue[T](max(k, 0)), k}
+}
+
+func NewBottomK[T any](k int) BoundedPQueue[T] {
+	return BoundedPQueue[T]{NewMaxPQueue[T](max(k, 0)), k}
+}
+
+func (b BoundedPQueue[T]) Clone() BoundedPQueue[T] {
+	return BoundedPQueue[T]{b.pq.Clone(), b.limit}
+}
+
+func (b *BoundedPQueue[T]) Enqueue(value T, priority int32) (kept bool, evicted Item[T], didEvict bool) {
+	if b.limit <= 0 {
+		return false, Item[T]{}, false
+	}
+
+	if len(b.pq.heap) < b.limit {
+		b.pq.Enqueue(value, priority)
+		return true, Item[T]{}, false
+	}
+
+	root := b.pq.heap[0]
+
+	if !b.pq.compare(root.Priority, priority) {
+		return false, Item[T]{}, false
+	}
+
+	b.pq.heap[0] = Item[T]{value, priority}
+	b.pq.heapifyDown(0)
+	return true, root, true
+}
+
+func (b BoundedPQueue[T]) Worst() (Item[T], error) {
+	return b.pq.Peek()
+}
+
+func (b BoundedPQueue[T]) Results() []Item[T] {
+	clone := b.pq.Clone()
+	results := make([]Item[T], 0, clone.Size())
+
+	for !clone.IsEmpty() {
+		item, _ := clone.Dequeue()
+		results = append(results, item)
+	}
+
+	slices.Reverse(results)
+	return results
+}
+
+func (b *BoundedPQueue[T]) Clear() {
+	b.pq.Clear()
+}
+
+func (b BoundedPQueue[T]) IsEmpty() bool {
+	return b.pq.IsEmpty()
+}
+
+func (b BoundedPQueue[T]) IsFull() bool {
+	return b.pq.Size() >= b.limit
+}
+
+func (b BoundedPQueue[T]) Size() int {
+	return b.pq.Size()
+}
+
+func (b BoundedPQueue[T]) Limit() int {
+	return b.limit
+}
+
+func (b BoundedPQueue[T]) String() string {
+	var builder strings.Builder
+
+	if b.pq.compare(0, 1) {
+		builder.WriteString("Top")
+	} else {
+		builder.WriteString("Bottom")
+	}
+
+	builder.WriteString(fmt.Sprintf("%d[", b.limit))
+
+	for i, item := range b.Results() {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+
+		builder.WriteString(fmt.Sprintf("(%v:%d)", item.Value, item.Priority))
+	}
+
+	builder.WriteString("]")
+	return builder.String()
+}
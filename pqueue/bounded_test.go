@@ -0,0 +1,132 @@
+package pqueue
+
+import (
+	"testing"
+)
+
+func TestBoundedTopK(t *testing.T) {
+	top := NewTopK[string](3)
+
+	if top.Limit() != 3 {
+		t.Errorf("Expected limit 3, got %d instead", top.Limit())
+	}
+
+	for _, item := range []Item[string]{{"a", 5}, {"b", 1}, {"c", 7}} {
+		kept, _, didEvict := top.Enqueue(item.Value, item.Priority)
+
+		if !kept || didEvict {
+			t.Errorf("Expected %s to be kept without eviction", item.Value)
+		}
+	}
+
+	if !top.IsFull() {
+		t.Error("Expected true, got false instead")
+	}
+
+	kept, _, didEvict := top.Enqueue("d", 0)
+
+	if kept || didEvict {
+		t.Error("Expected the worse item to be discarded")
+	}
+
+	kept, _, _ = top.Enqueue("e", 1)
+
+	if kept {
+		t.Error("Expected an item tied with the worst one to be discarded")
+	}
+
+	kept, evicted, didEvict := top.Enqueue("f", 6)
+
+	if !kept || !didEvict {
+		t.Error("Expected the better item to be kept and the worst one evicted")
+	}
+
+	if evicted.Value != "b" || evicted.Priority != 1 {
+		t.Errorf("Expected (b:1) to be evicted, got (%s:%d) instead", evicted.Value, evicted.Priority)
+	}
+
+	worst, err := top.Worst()
+
+	if err != nil {
+		t.Errorf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	if worst.Value != "a" {
+		t.Errorf("Expected value a, got %s instead", worst.Value)
+	}
+
+	results := top.Results()
+	expected := []Item[string]{{"c", 7}, {"f", 6}, {"a", 5}}
+
+	if len(results) != len(expected) {
+		t.Fatalf("Expected %d results, got %d instead", len(expected), len(results))
+	}
+
+	for i := range expected {
+		if results[i] != expected[i] {
+			t.Errorf("Expected %v, got %v instead", expected[i], results[i])
+		}
+	}
+
+	if top.Size() != 3 {
+		t.Errorf("Expected size 3, got %d instead", top.Size())
+	}
+
+	if top.String() != "Top3[(c:7), (f:6), (a:5)]" {
+		t.Errorf("Expected 'Top3[(c:7), (f:6), (a:5)]', got '%s' instead", top.String())
+	}
+}
+
+func TestBoundedBottomK(t *testing.T) {
+	bottom := NewBottomK[int](4)
+
+	for i := range 1000 {
+		bottom.Enqueue(i, int32((i*7919)%1000))
+	}
+
+	results := bottom.Results()
+
+	if len(results) != 4 {
+		t.Fatalf("Expected 4 results, got %d instead", len(results))
+	}
+
+	for i, item := range results {
+		if item.Priority != int32(i) {
+			t.Errorf("Expected priority %d, got %d instead", i, item.Priority)
+		}
+	}
+
+	if bottom.String() != "Bottom4[(0:0), (679:1), (358:2), (37:3)]" {
+		t.Errorf("Expected 'Bottom4[(0:0), (679:1), (358:2), (37:3)]', got '%s' instead", bottom.String())
+	}
+
+	clone := bottom.Clone()
+	bottom.Clear()
+
+	if !bottom.IsEmpty() {
+		t.Errorf("Expected size 0, got %d instead", bottom.Size())
+	}
+
+	if clone.Size() != 4 {
+		t.Errorf("Expected size 4, got %d instead", clone.Size())
+	}
+
+	_, err := bottom.Worst()
+
+	if err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+}
+
+func TestBoundedZeroLimit(t *testing.T) {
+	top := NewTopK[int](0)
+	kept, _, didEvict := top.Enqueue(1, 100)
+
+	if kept || didEvict {
+		t.Error("Expected nothing to be kept")
+	}
+
+	if !top.IsEmpty() {
+		t.Errorf("Expected size 0, got %d instead", top.Size())
+	}
+}
@@ -0,0 +1,124 @@
+package pqueue
+
+import (
+	"container/heap"
+	"errors"
+)
+
+var nonBinaryHeapError error
+var emptyHeapQueueError error
+
+func init() {
+	nonBinaryHeapError = errors.New("container/heap requires a binary priority queue")
+	emptyHeapQueueError = errors.New("empty heap queue")
+}
+
+type HeapAdapter[T any] struct {
+	pq *PQueue[T]
+}
+
+func (p *PQueue[T]) AsHeap() (HeapAdapter[T], error) {
+	if p.arity != DefaultArity {
+		return HeapAdapter[T]{}, nonBinaryHeapError
+	}
+
+	return HeapAdapter[T]{p}, nil
+}
+
+func (h HeapAdapter[T]) Len() int {
+	return len(h.pq.heap)
+}
+
+func (h HeapAdapter[T]) Less(i int, j int) bool {
+	return h.pq.less(h.pq.heap[i], h.pq.heap[j])
+}
+
+func (h HeapAdapter[T]) Swap(i int, j int) {
+	h.pq.mutate()
+	h.pq.heap[i], h.pq.heap[j] = h.pq.heap[j], h.pq.heap[i]
+}
+
+func (h HeapAdapter[T]) Top() Item[T] {
+	return h.pq.heap[0]
+}
+
+func (h HeapAdapter[T]) Push(x any) {
+	h.pq.mutate()
+	h.pq.heap = append(h.pq.heap, x.(Item[T]))
+}
+
+func (h HeapAdapter[T]) Pop() any {
+	h.pq.mutate()
+	last := len(h.pq.heap) - 1
+	item := h.pq.heap[last]
+	h.pq.heap = h.pq.heap[:last]
+	return item
+}
+
+// Heap is an optional extension of heap.Interface. Top returns the element at
+// index 0 without removing it and is only called on a non-empty heap.
+type Heap[E any] interface {
+	heap.Interface
+	Top() E
+}
+
+type HeapQueue[E any] struct {
+	h heap.Interface
+}
+
+func WrapHeap[E any](h heap.Interface) HeapQueue[E] {
+	heap.Init(h)
+	return HeapQueue[E]{h}
+}
+
+func (q HeapQueue[E]) Unwrap() heap.Interface {
+	return q.h
+}
+
+func (q HeapQueue[E]) Enqueue(value E) {
+	heap.Push(q.h, value)
+}
+
+func (q HeapQueue[E]) Dequeue() (E, error) {
+	if q.h.Len() == 0 {
+		var zero E
+		return zero, emptyHeapQueueError
+	}
+
+	return heap.Pop(q.h).(E), nil
+}
+
+func (q HeapQueue[E]) Peek() (E, error) {
+	if q.h.Len() == 0 {
+		var zero E
+		return zero, emptyHeapQueueError
+	}
+
+	if h, ok := q.h.(Heap[E]); ok {
+		return h.Top(), nil
+	}
+
+	// Without Top the root can only be reached by popping it. Pushing it back
+	// leaves the heap valid, though its layout may change.
+	top := heap.Pop(q.h)
+	heap.Push(q.h, top)
+	return top.(E), nil
+}
+
+func (q HeapQueue[E]) Fix(index int) {
+	heap.Fix(q.h, index)
+}
+
+func (q HeapQueue[E]) Clear() {
+	for q.h.Len() > 0 {
+		q.h.Pop()
+	}
+}
+
+func (q HeapQueue[E]) IsEmpty() bool {
+	return q.h.Len() == 0
+}
+
+func (q HeapQueue[E]) Size() int {
+	return q.h.Len()
+}
@@ -0,0 +1,194 @@
+package pqueue
+
+import (
+	"container/heap"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+type intHeap []int
+
+func (h intHeap) Len() int           { return len(h) }
+func (h intHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h intHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *intHeap) Push(x any)        { *h = append(*h, x.(int)) }
+func (h intHeap) Top() int           { return h[0] }
+
+func (h *intHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+func TestPQAsHeap(t *testing.T) {
+	pq := NewMaxPQueue[string]()
+	pq.Enqueue("b", 2)
+	pq.Enqueue("d", 4)
+
+	h, err := pq.AsHeap()
+
+	if err != nil {
+		t.Fatalf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	heap.Push(h, Item[string]{"a", 1})
+	heap.Push(h, Item[string]{"e", 5})
+	pq.Enqueue("c", 3)
+
+	if !isValidHeap(pq) {
+		t.Error("Heap invariant is violated")
+	}
+
+	if h.Len() != pq.Size() {
+		t.Errorf("Expected length %d, got %d instead", pq.Size(), h.Len())
+	}
+
+	item := heap.Pop(h).(Item[string])
+
+	if item.Value != "e" {
+		t.Errorf("Expected value e, got %s instead", item.Value)
+	}
+
+	item, _ = pq.Dequeue()
+
+	if item.Value != "d" {
+		t.Errorf("Expected value d, got %s instead", item.Value)
+	}
+
+	pq.GetSlice()[len(pq.GetSlice())-1].Priority = 10
+	heap.Fix(h, len(pq.GetSlice())-1)
+
+	if !isValidHeap(pq) {
+		t.Error("Heap invariant is violated")
+	}
+
+	item, _ = pq.Peek()
+
+	if item.Priority != 10 {
+		t.Errorf("Expected priority 10, got %d instead", item.Priority)
+	}
+
+	quad := NewDAryPQueue[int](true, 4)
+
+	if _, err := quad.AsHeap(); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+}
+
+func TestPQAsHeapMatchesInvariants(t *testing.T) {
+	rng := rand.New(rand.NewSource(9))
+	native := NewMinPQueue[int]()
+	adapted := NewMinPQueue[int]()
+	h, _ := adapted.AsHeap()
+
+	for i := range 500 {
+		priority := int32(rng.Intn(100))
+		native.Enqueue(i, priority)
+		heap.Push(h, Item[int]{i, priority})
+
+		if i%5 == 4 {
+			nativeItem, _ := native.Dequeue()
+			adaptedItem := heap.Pop(h).(Item[int])
+
+			if nativeItem.Priority != adaptedItem.Priority {
+				t.Fatalf("Expected priority %d, got %d instead", nativeItem.Priority, adaptedItem.Priority)
+			}
+		}
+
+		if !isValidHeap(adapted) {
+			t.Fatal("Heap invariant is violated")
+		}
+	}
+
+	if !slices.Equal(drainPriorities(native), drainPriorities(adapted)) {
+		t.Error("Expected native and adapted queues to dequeue in the same order")
+	}
+}
+
+func TestWrapHeap(t *testing.T) {
+	raw := &intHeap{5, 2, 8, 1}
+	q := WrapHeap[int](raw)
+
+	if q.Size() != 4 {
+		t.Errorf("Expected size 4, got %d instead", q.Size())
+	}
+
+	q.Enqueue(0)
+	heap.Push(raw, 3)
+
+	before := slices.Clone(*raw)
+	value, err := q.Peek()
+
+	if err != nil {
+		t.Errorf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	if value != 0 {
+		t.Errorf("Expected 0, got %d instead", value)
+	}
+
+	if !slices.Equal(before, *raw) {
+		t.Errorf("Expected Peek to leave the heap untouched, got %v instead of %v", *raw, before)
+	}
+
+	var values []int
+
+	for !q.IsEmpty() {
+		value, _ := q.Dequeue()
+		values = append(values, value)
+	}
+
+	if !slices.Equal(values, []int{0, 1, 2, 3, 5, 8}) {
+		t.Errorf("Expected %v, got %v instead", []int{0, 1, 2, 3, 5, 8}, values)
+	}
+
+	if _, err := q.Dequeue(); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	if _, err := q.Peek(); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	q.Enqueue(4)
+	q.Enqueue(7)
+	(*raw)[1] = -1
+	q.Fix(1)
+
+	if value, _ := q.Peek(); value != -1 {
+		t.Errorf("Expected -1, got %d instead", value)
+	}
+
+	if q.Unwrap() != raw {
+		t.Error("Expected the wrapped heap to be returned")
+	}
+
+	q.Clear()
+
+	if !q.IsEmpty() {
+		t.Errorf("Expected size 0, got %d instead", q.Size())
+	}
+}
+
+func TestWrapHeapWithoutTop(t *testing.T) {
+	raw := &intHeap{5, 2, 8, 1}
+	q := WrapHeap[int](struct{ heap.Interface }{raw})
+
+	if value, err := q.Peek(); err != nil || value != 1 || q.Size() != 4 {
+		t.Errorf("Expected 1 and size 4, got %d and %d (%v)", value, q.Size(), err)
+	}
+
+	var values []int
+
+	for !q.IsEmpty() {
+		value, _ := q.Dequeue()
+		values = append(values, value)
+	}
+
+	if !slices.Equal(values, []int{1, 2, 5, 8}) {
+		t.Errorf("Expected %v, got %v instead", []int{1, 2, 5, 8}, values)
+	}
+}
@@ -0,0 +1,119 @@
+package pqueue
+
+import (
+	"io"
+	"iter"
+)
+
+type cursor[E any] struct {
+	value  E
+	source int
+	next   func() (E, bool)
+}
+
+func Merge[E any](compare func(E, E) int, seqs ...iter.Seq[E]) iter.Seq[E] {
+	return merge(compare, false, false, seqs)
+}
+
+func MergeStable[E any](compare func(E, E) int, seqs ...iter.Seq[E]) iter.Seq[E] {
+	return merge(compare, true, false, seqs)
+}
+
+func MergeUnique[E any](compare func(E, E) int, seqs ...iter.Seq[E]) iter.Seq[E] {
+	return merge(compare, true, true, seqs)
+}
+
+func MergeReaders[E any](compare func(E, E) int, stable bool, readers ...func() (E, error)) iter.Seq2[E, error] {
+	return func(yield func(E, error) bool) {
+		seqs := make([]iter.Seq[E], len(readers))
+		var readErr error
+
+		for i, read := range readers {
+			seqs[i] = func(yield func(E) bool) {
+				for readErr == nil {
+					val, err := read()
+
+					if err == io.EOF {
+						return
+					}
+
+					if err != nil {
+						readErr = err
+						return
+					}
+
+					if !yield(val) {
+						return
+					}
+				}
+			}
+		}
+
+		for val := range merge(compare, stable, false, seqs) {
+			if readErr != nil {
+				break
+			}
+
+			if !yield(val, nil) {
+				return
+			}
+		}
+
+		if readErr != nil {
+			var zero E
+			yield(zero, readErr)
+		}
+	}
+}
+
+func merge[E any](compare func(E, E) int, stable bool, unique bool, seqs []iter.Seq[E]) iter.Seq[E] {
+	less := func(lhs *cursor[E], rhs *cursor[E]) bool {
+		c := compare(lhs.value, rhs.value)
+
+		if c == 0 && stable {
+			return lhs.source < rhs.source
+		}
+
+		return c < 0
+	}
+
+	return func(yield func(E) bool) {
+		heap := make([]*cursor[E], 0, len(seqs))
+
+		for i, seq := range seqs {
+			next, stop := iter.Pull(seq)
+			defer stop()
+
+			if val, ok := next(); ok {
+				heap = append(heap, &cursor[E]{val, i, next})
+			}
+		}
+
+		heapify(heap, DefaultArity, less)
+		var last E
+		emitted := false
+
+		for len(heap) > 0 {
+			top := heap[0]
+			val := top.value
+
+			if !unique || !emitted || compare(last, val) != 0 {
+				if !yield(val) {
+					return
+				}
+
+				last = val
+				emitted = true
+			}
+
+			if next, ok := top.next(); ok {
+				top.value = next
+			} else {
+				heap[0] = heap[len(heap)-1]
+				heap = heap[:len(heap)-1]
+			}
+
+			siftDown(heap, 0, DefaultArity, less)
+		}
+	}
+}
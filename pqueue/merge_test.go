@@ -0,0 +1,136 @@
+package pqueue
+
+import (
+	"cmp"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+)
+
+type record struct {
+	key    int
+	source string
+}
+
+func compareRecords(lhs record, rhs record) int {
+	return cmp.Compare(lhs.key, rhs.key)
+}
+
+func reader[E any](values ...E) func() (E, error) {
+	return func() (E, error) {
+		if len(values) == 0 {
+			var zero E
+			return zero, io.EOF
+		}
+
+		val := values[0]
+		values = values[1:]
+		return val, nil
+	}
+}
+
+func TestMerge(t *testing.T) {
+	merged := slices.Collect(Merge(cmp.Compare[int],
+		slices.Values([]int{1, 4, 7, 10}),
+		slices.Values([]int{}),
+		slices.Values([]int{2, 2, 5, 8}),
+		slices.Values([]int{0, 3, 6, 9, 11, 12}),
+	))
+
+	expected := []int{0, 1, 2, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+
+	if !slices.Equal(merged, expected) {
+		t.Errorf("Expected %v, got %v instead", expected, merged)
+	}
+
+	if merged := slices.Collect(Merge[int](cmp.Compare[int])); len(merged) != 0 {
+		t.Errorf("Expected an empty sequence, got %v instead", merged)
+	}
+
+	count := 0
+
+	for range Merge(cmp.Compare[int], slices.Values([]int{1, 3}), slices.Values([]int{2, 4})) {
+		count++
+
+		if count == 2 {
+			break
+		}
+	}
+
+	if count != 2 {
+		t.Errorf("Expected 2 items, got %d instead", count)
+	}
+}
+
+func TestMergeStable(t *testing.T) {
+	a := []record{{1, "a"}, {2, "a"}, {2, "a"}, {3, "a"}}
+	b := []record{{1, "b"}, {2, "b"}, {4, "b"}}
+	c := []record{{2, "c"}, {3, "c"}}
+
+	merged := slices.Collect(MergeStable(compareRecords, slices.Values(a), slices.Values(b), slices.Values(c)))
+	expected := []record{{1, "a"}, {1, "b"}, {2, "a"}, {2, "a"}, {2, "b"}, {2, "c"}, {3, "a"}, {3, "c"}, {4, "b"}}
+
+	if !slices.Equal(merged, expected) {
+		t.Errorf("Expected %v, got %v instead", expected, merged)
+	}
+
+	merged = slices.Collect(MergeStable(compareRecords, slices.Values(c), slices.Values(b), slices.Values(a)))
+	expected = []record{{1, "b"}, {1, "a"}, {2, "c"}, {2, "b"}, {2, "a"}, {2, "a"}, {3, "c"}, {3, "a"}, {4, "b"}}
+
+	if !slices.Equal(merged, expected) {
+		t.Errorf("Expected %v, got %v instead", expected, merged)
+	}
+}
+
+func TestMergeUnique(t *testing.T) {
+	a := []record{{1, "a"}, {2, "a"}, {2, "a"}, {5, "a"}}
+	b := []record{{1, "b"}, {3, "b"}, {5, "b"}}
+
+	merged := slices.Collect(MergeUnique(compareRecords, slices.Values(a), slices.Values(b)))
+	expected := []record{{1, "a"}, {2, "a"}, {3, "b"}, {5, "a"}}
+
+	if !slices.Equal(merged, expected) {
+		t.Errorf("Expected %v, got %v instead", expected, merged)
+	}
+}
+
+func TestMergeReaders(t *testing.T) {
+	var merged []int
+
+	for val, err := range MergeReaders(cmp.Compare[int], true, reader(1, 5, 9), reader(2, 3), reader[int]()) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		merged = append(merged, val)
+	}
+
+	if !slices.Equal(merged, []int{1, 2, 3, 5, 9}) {
+		t.Errorf("Expected %v, got %v instead", []int{1, 2, 3, 5, 9}, merged)
+	}
+
+	failure := errors.New("disk on fire")
+	calls := 0
+	failing := func() (int, error) {
+		calls++
+
+		if calls > 2 {
+			return 0, failure
+		}
+
+		return calls * 10, nil
+	}
+
+	var gotErr error
+
+	for _, err := range MergeReaders(cmp.Compare[int], false, reader(1, 2, 3, 100), failing) {
+		if err != nil {
+			gotErr = err
+		}
+	}
+
+	if !errors.Is(gotErr, failure) {
+		t.Errorf("Expected '%v', got '%v' instead", failure, gotErr)
+	}
+}
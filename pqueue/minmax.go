@@ -0,0 +1,220 @@
+package pqueue
+
+import (
+	"fmt"
+	"math/bits"
+	"slices"
+	"strings"
+)
+
+type MinMaxPQueue[T any] struct {
+	heap []Item[T]
+}
+
+func NewMinMaxPQueue[T any](capacity ...int) MinMaxPQueue[T] {
+	initialCapacity := 0
+
+	if len(capacity) > 0 {
+		initialCapacity = capacity[0]
+	}
+
+	return MinMaxPQueue[T]{make([]Item[T], 0, initialCapacity)}
+}
+
+func (m MinMaxPQueue[T]) Clone() MinMaxPQueue[T] {
+	return MinMaxPQueue[T]{slices.Clone(m.heap)}
+}
+
+func (m *MinMaxPQueue[T]) Enqueue(value T, priority int32) {
+	m.heap = append(m.heap, Item[T]{value, priority})
+	m.pushUp(len(m.heap) - 1)
+}
+
+func (m MinMaxPQueue[T]) PeekMin() (Item[T], error) {
+	if len(m.heap) == 0 {
+		return Item[T]{}, emptyPQError
+	}
+
+	return m.heap[0], nil
+}
+
+func (m MinMaxPQueue[T]) PeekMax() (Item[T], error) {
+	if len(m.heap) == 0 {
+		return Item[T]{}, emptyPQError
+	}
+
+	return m.heap[m.maxIndex()], nil
+}
+
+func (m *MinMaxPQueue[T]) DequeueMin() (Item[T], error) {
+	if len(m.heap) == 0 {
+		return Item[T]{}, emptyPQError
+	}
+
+	return m.removeAt(0), nil
+}
+
+func (m *MinMaxPQueue[T]) DequeueMax() (Item[T], error) {
+	if len(m.heap) == 0 {
+		return Item[T]{}, emptyPQError
+	}
+
+	return m.removeAt(m.maxIndex()), nil
+}
+
+func (m *MinMaxPQueue[T]) Clear() {
+	m.heap = m.heap[:0]
+}
+
+func (m MinMaxPQueue[T]) IsEmpty() bool {
+	return len(m.heap) == 0
+}
+
+func (m MinMaxPQueue[T]) Size() int {
+	return len(m.heap)
+}
+
+func (m MinMaxPQueue[T]) Capacity() int {
+	return cap(m.heap)
+}
+
+func (m MinMaxPQueue[T]) String() string {
+	var builder strings.Builder
+	builder.WriteString("MinMaxPQueue[")
+
+	clone := m.Clone()
+	first := true
+
+	for !clone.IsEmpty() {
+		if !first {
+			builder.WriteString(", ")
+		}
+
+		item, err := clone.DequeueMin()
+
+		if err != nil {
+			panic(err)
+		}
+
+		builder.WriteString(fmt.Sprintf("(%v:%d)", item.Value, item.Priority))
+		first = false
+	}
+
+	builder.WriteString("]")
+	return builder.String()
+}
+
+func (m MinMaxPQueue[T]) maxIndex() int {
+	switch len(m.heap) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+
+	if m.heap[1].Priority >= m.heap[2].Priority {
+		return 1
+	}
+
+	return 2
+}
+
+func (m *MinMaxPQueue[T]) removeAt(index int) Item[T] {
+	last := len(m.heap) - 1
+	res := m.heap[index]
+	m.heap[index] = m.heap[last]
+	m.heap = m.heap[:last]
+
+	if index < last {
+		m.pushDown(index)
+	}
+
+	return res
+}
+
+func isMinLevel(index int) bool {
+	return bits.Len(uint(index+1))%2 == 1
+}
+
+func (m MinMaxPQueue[T]) pushUp(curr int) {
+	if curr == 0 {
+		return
+	}
+
+	parent := (curr - 1) >> 1
+	compare := maxCompare
+
+	if isMinLevel(curr) {
+		compare = minCompare
+	}
+
+	if compare(m.heap[parent].Priority, m.heap[curr].Priority) {
+		m.heap[curr], m.heap[parent] = m.heap[parent], m.heap[curr]
+		m.pushUpLevel(parent, oppositeCompare(compare))
+	} else {
+		m.pushUpLevel(curr, compare)
+	}
+}
+
+func (m MinMaxPQueue[T]) pushUpLevel(curr int, compare func(int32, int32) bool) {
+	for curr > 2 {
+		grandparent := (curr - 3) >> 2
+
+		if !compare(m.heap[curr].Priority, m.heap[grandparent].Priority) {
+			break
+		}
+
+		m.heap[curr], m.heap[grandparent] = m.heap[grandparent], m.heap[curr]
+		curr = grandparent
+	}
+}
+
+func (m MinMaxPQueue[T]) pushDown(curr int) {
+	compare := maxCompare
+
+	if isMinLevel(curr) {
+		compare = minCompare
+	}
+
+	size := len(m.heap)
+
+	for {
+		firstChild := (curr << 1) + 1
+
+		if firstChild >= size {
+			return
+		}
+
+		opt := firstChild
+		firstGrandchild := (firstChild << 1) + 1
+		candidates := [...]int{firstChild + 1, firstGrandchild, firstGrandchild + 1, firstGrandchild + 2, firstGrandchild + 3}
+
+		for _, candidate := range candidates {
+			if candidate < size && compare(m.heap[candidate].Priority, m.heap[opt].Priority) {
+				opt = candidate
+			}
+		}
+
+		if !compare(m.heap[opt].Priority, m.heap[curr].Priority) {
+			return
+		}
+
+		m.heap[curr], m.heap[opt] = m.heap[opt], m.heap[curr]
+
+		if opt <= firstChild+1 {
+			return
+		}
+
+		parent := (opt - 1) >> 1
+
+		if compare(m.heap[parent].Priority, m.heap[opt].Priority) {
+			m.heap[opt], m.heap[parent] = m.heap[parent], m.heap[opt]
+		}
+
+		curr = opt
+	}
+}
+
+func oppositeCompare(compare func(int32, int32) bool) func(int32, int32) bool {
+	return func(lhs int32, rhs int32) bool { return compare(rhs, lhs) }
+}
@@ -0,0 +1,136 @@
+package pqueue
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestMinMaxPeekDequeue(t *testing.T) {
+	mm := NewMinMaxPQueue[string]()
+
+	_, err := mm.PeekMin()
+
+	if err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	_, err = mm.DequeueMax()
+
+	if err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	mm.Enqueue("c", 3)
+	mm.Enqueue("a", 1)
+	mm.Enqueue("e", 5)
+	mm.Enqueue("b", 2)
+	mm.Enqueue("d", 4)
+
+	item, err := mm.PeekMin()
+
+	if err != nil {
+		t.Errorf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	if item.Value != "a" {
+		t.Errorf("Expected value a, got %s instead", item.Value)
+	}
+
+	item, _ = mm.PeekMax()
+
+	if item.Value != "e" {
+		t.Errorf("Expected value e, got %s instead", item.Value)
+	}
+
+	item, _ = mm.DequeueMax()
+
+	if item.Value != "e" {
+		t.Errorf("Expected value e, got %s instead", item.Value)
+	}
+
+	item, _ = mm.DequeueMin()
+
+	if item.Value != "a" {
+		t.Errorf("Expected value a, got %s instead", item.Value)
+	}
+
+	if mm.Size() != 3 {
+		t.Errorf("Expected size 3, got %d instead", mm.Size())
+	}
+
+	if mm.String() != "MinMaxPQueue[(b:2), (c:3), (d:4)]" {
+		t.Errorf("Expected 'MinMaxPQueue[(b:2), (c:3), (d:4)]', got '%s' instead", mm.String())
+	}
+
+	mm.Clear()
+
+	if !mm.IsEmpty() {
+		t.Errorf("Expected size 0, got %d instead", mm.Size())
+	}
+
+	mm.Enqueue("x", 7)
+	minItem, _ := mm.PeekMin()
+	maxItem, _ := mm.PeekMax()
+
+	if minItem.Value != "x" || maxItem.Value != "x" {
+		t.Errorf("Expected value x at both ends, got %s and %s instead", minItem.Value, maxItem.Value)
+	}
+}
+
+func TestMinMaxRandomized(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	mm := NewMinMaxPQueue[int](16)
+	var reference []int32
+
+	for i := range 5000 {
+		switch op := rng.Intn(4); {
+		case op <= 1 || len(reference) == 0:
+			priority := int32(rng.Intn(200) - 100)
+			mm.Enqueue(i, priority)
+			reference = append(reference, priority)
+		case op == 2:
+			item, err := mm.DequeueMin()
+
+			if err != nil {
+				t.Fatalf("Expected no error, got '%s' instead", err.Error())
+			}
+
+			index := slices.Index(reference, slices.Min(reference))
+
+			if item.Priority != reference[index] {
+				t.Fatalf("Expected priority %d, got %d instead", reference[index], item.Priority)
+			}
+
+			reference = slices.Delete(reference, index, index+1)
+		default:
+			item, err := mm.DequeueMax()
+
+			if err != nil {
+				t.Fatalf("Expected no error, got '%s' instead", err.Error())
+			}
+
+			index := slices.Index(reference, slices.Max(reference))
+
+			if item.Priority != reference[index] {
+				t.Fatalf("Expected priority %d, got %d instead", reference[index], item.Priority)
+			}
+
+			reference = slices.Delete(reference, index, index+1)
+		}
+
+		if mm.Size() != len(reference) {
+			t.Fatalf("Expected size %d, got %d instead", len(reference), mm.Size())
+		}
+
+		if len(reference) > 0 {
+			minItem, _ := mm.PeekMin()
+			maxItem, _ := mm.PeekMax()
+
+			if minItem.Priority != slices.Min(reference) || maxItem.Priority != slices.Max(reference) {
+				t.Fatalf("Expected bounds %d and %d, got %d and %d instead",
+					slices.Min(reference), slices.Max(reference), minItem.Priority, maxItem.Priority)
+			}
+		}
+	}
+}
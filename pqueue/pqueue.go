@@ -3,8 +3,13 @@ package pqueue
 import (
 	"errors"
 	"fmt"
+	"iter"
+	"math/bits"
 	"slices"
 	"strings"
+	"time"
+
+	"github.com/XeniaPhe/xengods/instrument"
 )
 
 var emptyPQError error
@@ -21,9 +26,15 @@ type Item[T any] struct {
 	Priority int32
 }
 
+const DefaultArity = 2
+
 type PQueue[T any] struct {
 	heap []Item[T]
 	compare func(int32, int32) bool
+	arity int
+	hook instrument.Hook
+	version uint64
+	shared bool
 }
 
 func NewMinPQueue[T any](capacity ...int) PQueue[T] {
@@ -33,7 +44,7 @@ func NewMinPQueue[T any](capacity ...int) PQueue[T] {
 		initialCapacity = capacity[0]
 	}
 
-	return PQueue[T]{make([]Item[T], 0, initialCapacity), minCompare}
+	return PQueue[T]{make([]Item[T], 0, initialCapacity), minCompare, DefaultArity, nil, 0, false}
 }
 
 func NewMaxPQueue[T any](capacity ...int) PQueue[T] {
@@ -43,7 +54,7 @@ func NewMaxPQueue[T any](capacity ...int) PQueue[T] {
 		initialCapacity = capacity[0]
 	}
 
-	return PQueue[T]{make([]Item[T], 0, initialCapacity), maxCompare}
+	return PQueue[T]{make([]Item[T], 0, initialCapacity), maxCompare, DefaultArity, nil, 0, false}
 }
 
 func NewPQueue[T any](min bool, capacity ...int) PQueue[T] {
@@ -54,12 +65,54 @@ func NewPQueue[T any](min bool, capacity ...int) PQueue[T] {
 	return NewMaxPQueue[T](capacity...)
 }
 
+func NewDAryPQueue[T any](min bool, arity int, capacity ...int) PQueue[T] {
+	pq := NewPQueue[T](min, capacity...)
+	pq.arity = max(arity, DefaultArity)
+	return pq
+}
+
+func FromItems[T any](min bool, items ...Item[T]) PQueue[T] {
+	pq := NewPQueue[T](min, len(items))
+	pq.heap = append(pq.heap, items...)
+	pq.heapify()
+	return pq
+}
+
+func FromSlice[T any](min bool, values []T, priorityOf func(T) int32) PQueue[T] {
+	pq := NewPQueue[T](min, len(values))
+
+	for _, val := range values {
+		pq.heap = append(pq.heap, Item[T]{val, priorityOf(val)})
+	}
+
+	pq.heapify()
+	return pq
+}
+
+func (p PQueue[T]) Arity() int {
+	return p.arity
+}
+
 func (p PQueue[T]) IsInitialized() bool {
 	return p.compare != nil
 }
 
 func (p *PQueue[T]) InitializeIfNot(min bool) {
+	if p.compare != nil {
+		return
+	}
+
+	p.Reset(min)
+}
+
+func (p *PQueue[T]) Reset(min bool) {
 	p.heap = make([]Item[T], 0)
+	p.shared = false
+	p.version++
+
+	if p.arity < DefaultArity {
+		p.arity = DefaultArity
+	}
 
 	if min {
 		p.compare = minCompare
@@ -69,29 +122,67 @@ func (p *PQueue[T]) InitializeIfNot(min bool) {
 }
 
 func (p PQueue[T]) Clone() PQueue[T] {
-	return PQueue[T]{slices.Clone(p.heap), p.compare}
+	return PQueue[T]{slices.Clone(p.heap), p.compare, p.arity, nil, 0, false}
+}
+
+func (p *PQueue[T]) SetHook(hook instrument.Hook) {
+	p.hook = hook
 }
 
 func (p *PQueue[T]) Enqueue(value T, priority int32) {
+	if p.hook != nil {
+		defer p.observe(instrument.OpEnqueue, time.Now())
+	}
+
+	p.mutate()
 	p.heap = append(p.heap, Item[T]{value, priority})
-	p.heapifyUp()
+	p.heapifyUp(len(p.heap) - 1)
+}
+
+func (p *PQueue[T]) EnqueueAll(items ...Item[T]) {
+	if p.hook != nil {
+		defer p.observeItems(instrument.OpEnqueue, len(items), time.Now())
+	}
+
+	p.mutate()
+	size := len(p.heap)
+	p.heap = append(p.heap, items...)
+
+	if len(items)*bits.Len(uint(len(p.heap))) < len(p.heap) {
+		for i := size; i < len(p.heap); i++ {
+			p.heapifyUp(i)
+		}
+
+		return
+	}
+
+	p.heapify()
 }
 
 func (p *PQueue[T]) Dequeue() (Item[T], error) {
+	if p.hook != nil {
+		defer p.observe(instrument.OpDequeue, time.Now())
+	}
+
 	size := len(p.heap)
 	
 	if size == 0 {
 		return Item[T]{}, emptyPQError
 	}
 
+	p.mutate()
 	var res Item[T]
 	res, p.heap[0] = p.heap[0], p.heap[size-1]
 	p.heap = p.heap[:size-1]
-	p.heapifyDown()
+	p.heapifyDown(0)
 	return res, nil
 }
 
 func (p PQueue[T]) Peek() (Item[T], error) {
+	if p.hook != nil {
+		defer p.observe(instrument.OpPeek, time.Now())
+	}
+
 	if len(p.heap) == 0 {
 		return Item[T]{}, emptyPQError
 	}
@@ -99,16 +190,67 @@ func (p PQueue[T]) Peek() (Item[T], error) {
 	return p.heap[0], nil
 }
 
+// Deprecated: Mutating the returned slice can break the heap property; use View for reads and Fix or RemoveAt for changes.
 func (p PQueue[T]) GetSlice() []Item[T] {
 	return p.heap
 }
 
+// Deprecated: Mutating the returned slice can break the heap property; use View for reads and Fix or RemoveAt for changes.
 func (p *PQueue[T]) GetSlicePtr() *[]Item[T] {
+	p.mutate()
 	return &p.heap
 }
 
+func (p PQueue[T]) Sorted() iter.Seq[Item[T]] {
+	return func(yield func(Item[T]) bool) {
+		if len(p.heap) == 0 {
+			return
+		}
+
+		frontier := PQueue[int]{make([]Item[int], 0, p.arity), p.compare, DefaultArity, nil, 0, false}
+		frontier.Enqueue(0, p.heap[0].Priority)
+
+		for !frontier.IsEmpty() {
+			next, _ := frontier.Dequeue()
+			index := next.Value
+
+			if !yield(p.heap[index]) {
+				return
+			}
+
+			first := index*p.arity + 1
+			last := min(first+p.arity, len(p.heap))
+
+			for child := first; child < last; child++ {
+				frontier.Enqueue(child, p.heap[child].Priority)
+			}
+		}
+	}
+}
+
+func (p PQueue[T]) ToSortedSlice() []Item[T] {
+	sorted := make([]Item[T], 0, len(p.heap))
+
+	for item := range p.Sorted() {
+		sorted = append(sorted, item)
+	}
+
+	return sorted
+}
+
 func (p *PQueue[T]) Clear() {
-	p.heap = p.heap[:0]
+	if p.hook != nil {
+		defer p.observe(instrument.OpClear, time.Now())
+	}
+
+	if p.shared {
+		p.heap = make([]Item[T], 0, cap(p.heap))
+		p.shared = false
+	} else {
+		p.heap = p.heap[:0]
+	}
+
+	p.version++
 }
 
 func (p PQueue[T]) IsEmpty() bool {
@@ -132,21 +274,13 @@ func (p PQueue[T]) String() string {
 	}
 
 	builder.WriteString("PQueue[")
-
-	clone := p.Clone()
 	first := true
 
-	for !clone.IsEmpty() {
+	for item := range p.Sorted() {
 		if !first {
 			builder.WriteString(", ")
 		}
 
-		item, err := clone.Dequeue()
-		
-		if err != nil {
-			panic(err)
-		}
-		
 		builder.WriteString(fmt.Sprintf("(%v:%d)", item.Value, item.Priority))
 		first = false
 	}
@@ -155,49 +289,26 @@ func (p PQueue[T]) String() string {
 	return builder.String()
 }
 
-func (p PQueue[T]) heapifyUp() {
-	curr := len(p.heap) - 1
-
-	for {
-		if curr <= 0 {
-			return
-		}
-
-		parent := (curr - 1) >> 1
-		currPriority := p.heap[curr].Priority
-		parentPriority := p.heap[parent].Priority
-
-		if p.compare(parentPriority, currPriority) ||  parentPriority == currPriority {
-			break
-		}
-
-		p.heap[curr], p.heap[parent] = p.heap[parent], p.heap[curr]
-		curr = parent
-	}
+func (p *PQueue[T]) observe(op instrument.Op, start time.Time) {
+	instrument.Observe(p.hook, op, len(p.heap), start)
 }
 
-func (p PQueue[T]) heapifyDown() {
-	size := len(p.heap)
-	curr := 0
-	opt := curr
-
-	for {
-		left := (curr << 1) + 1
-		right := left + 1
+func (p *PQueue[T]) observeItems(op instrument.Op, items int, start time.Time) {
+	instrument.ObserveItems(p.hook, op, items, len(p.heap), start)
+}
 
-		if left < size && p.compare(p.heap[left].Priority, p.heap[opt].Priority) {
-			opt = left
-		}
+func (p PQueue[T]) heapify() {
+	heapify(p.heap, p.arity, p.less)
+}
 
-		if right < size && p.compare(p.heap[right].Priority, p.heap[opt].Priority) {
-			opt = right
-		}
+func (p PQueue[T]) heapifyUp(curr int) {
+	siftUp(p.heap, curr, p.arity, p.less)
+}
 
-		if curr == opt {
-			break
-		}
+func (p PQueue[T]) heapifyDown(curr int) {
+	siftDown(p.heap, curr, p.arity, p.less)
+}
 
-		p.heap[curr], p.heap[opt] = p.heap[opt], p.heap[curr]
-		curr = opt
-	}
+func (p PQueue[T]) less(lhs Item[T], rhs Item[T]) bool {
+	return p.compare(lhs.Priority, rhs.Priority)
 }
\ No newline at end of file
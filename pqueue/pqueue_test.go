@@ -1,7 +1,12 @@
 package pqueue
 
 import (
+	"fmt"
+	"math/rand"
+	"slices"
 	"testing"
+
+	"github.com/XeniaPhe/xengods/instrument"
 )
 
 func TestPQConstructors(t *testing.T) {
@@ -408,4 +413,369 @@ func TestPQString(t *testing.T) {
 	if str != "MaxPQueue[(7:8), (5:6), (3:4), (1:2), (9:0)]" {
 		t.Errorf("Expected 'MaxPQueue[(7:8), (5:6), (3:4), (1:2), (9:0)]', got '%s' instead", str)
 	}
-}
\ No newline at end of file
+}
+
+func isValidHeap[T any](pq PQueue[T]) bool {
+	for i := 1; i < len(pq.heap); i++ {
+		parent := (i - 1) / pq.arity
+
+		if pq.compare(pq.heap[i].Priority, pq.heap[parent].Priority) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func drainPriorities[T any](pq PQueue[T]) []int32 {
+	priorities := make([]int32, 0, pq.Size())
+
+	for !pq.IsEmpty() {
+		item, _ := pq.Dequeue()
+		priorities = append(priorities, item.Priority)
+	}
+
+	return priorities
+}
+
+func TestPQFromItemsFromSlice(t *testing.T) {
+	items := []Item[string]{{"e", 5}, {"a", 1}, {"d", 4}, {"b", 2}, {"c", 3}, {"f", 6}}
+	minpq := FromItems(true, items...)
+
+	if minpq.Size() != 6 {
+		t.Errorf("Expected size 6, got %d instead", minpq.Size())
+	}
+
+	if !isValidHeap(minpq) {
+		t.Error("Heap invariant is violated")
+	}
+
+	item, _ := minpq.Peek()
+
+	if item.Value != "a" {
+		t.Errorf("Expected value a, got %s instead", item.Value)
+	}
+
+	if items[0].Value != "e" {
+		t.Errorf("Expected the source slice to be untouched, got %v instead", items)
+	}
+
+	maxpq := FromItems(false, items...)
+	priorities := drainPriorities(maxpq)
+
+	if !slices.Equal(priorities, []int32{6, 5, 4, 3, 2, 1}) {
+		t.Errorf("Expected %v, got %v instead", []int32{6, 5, 4, 3, 2, 1}, priorities)
+	}
+
+	values := []int{9, 3, 7, 1, 8, 2}
+	pq := FromSlice(true, values, func(val int) int32 { return int32(val) })
+
+	if !isValidHeap(pq) {
+		t.Error("Heap invariant is violated")
+	}
+
+	priorities = drainPriorities(pq)
+
+	if !slices.Equal(priorities, []int32{1, 2, 3, 7, 8, 9}) {
+		t.Errorf("Expected %v, got %v instead", []int32{1, 2, 3, 7, 8, 9}, priorities)
+	}
+
+	empty := FromItems[int](true)
+
+	if !empty.IsEmpty() || !empty.IsInitialized() {
+		t.Error("Expected an empty initialized queue")
+	}
+}
+
+func TestPQEnqueueAll(t *testing.T) {
+	pq := NewMaxPQueue[int]()
+	pq.EnqueueAll(Item[int]{1, 1}, Item[int]{5, 5}, Item[int]{3, 3})
+
+	if !isValidHeap(pq) {
+		t.Error("Heap invariant is violated")
+	}
+
+	for i := range 100 {
+		pq.Enqueue(i, int32((i*37)%101))
+	}
+
+	pq.EnqueueAll(Item[int]{-1, 200}, Item[int]{-2, -5})
+
+	if !isValidHeap(pq) {
+		t.Error("Heap invariant is violated")
+	}
+
+	bulk := make([]Item[int], 0, 500)
+
+	for i := range 500 {
+		bulk = append(bulk, Item[int]{i, int32((i * 53) % 199)})
+	}
+
+	pq.EnqueueAll(bulk...)
+
+	if pq.Size() != 605 {
+		t.Errorf("Expected size 605, got %d instead", pq.Size())
+	}
+
+	if !isValidHeap(pq) {
+		t.Error("Heap invariant is violated")
+	}
+
+	priorities := drainPriorities(pq)
+
+	if !slices.IsSortedFunc(priorities, func(lhs int32, rhs int32) int { return int(rhs - lhs) }) {
+		t.Error("Expected priorities in descending order")
+	}
+
+	if priorities[0] != 200 || priorities[len(priorities)-1] != -5 {
+		t.Errorf("Expected bounds 200 and -5, got %d and %d instead", priorities[0], priorities[len(priorities)-1])
+	}
+}
+
+func TestPQArity(t *testing.T) {
+	if NewMinPQueue[int]().Arity() != DefaultArity {
+		t.Errorf("Expected arity %d, got %d instead", DefaultArity, NewMinPQueue[int]().Arity())
+	}
+
+	if NewDAryPQueue[int](true, 1).Arity() != DefaultArity {
+		t.Errorf("Expected arity %d, got %d instead", DefaultArity, NewDAryPQueue[int](true, 1).Arity())
+	}
+
+	var uninitialized PQueue[int]
+	uninitialized.InitializeIfNot(false)
+
+	if uninitialized.Arity() != DefaultArity {
+		t.Errorf("Expected arity %d, got %d instead", DefaultArity, uninitialized.Arity())
+	}
+
+	rng := rand.New(rand.NewSource(3))
+
+	for _, arity := range []int{2, 3, 4, 8, 16} {
+		for _, min := range []bool{true, false} {
+			pq := NewDAryPQueue[int](min, arity, 64)
+
+			if pq.Arity() != arity {
+				t.Errorf("Expected arity %d, got %d instead", arity, pq.Arity())
+			}
+
+			for i := range 1000 {
+				pq.Enqueue(i, int32(rng.Intn(500)))
+			}
+
+			if !isValidHeap(pq) {
+				t.Errorf("Heap invariant is violated for arity %d", arity)
+			}
+
+			clone := pq.Clone()
+
+			if clone.Arity() != arity {
+				t.Errorf("Expected arity %d, got %d instead", arity, clone.Arity())
+			}
+
+			bulk := make([]Item[int], 0, 2000)
+
+			for i := range 2000 {
+				bulk = append(bulk, Item[int]{i, int32(rng.Intn(500))})
+			}
+
+			pq.EnqueueAll(bulk...)
+
+			if !isValidHeap(pq) {
+				t.Errorf("Heap invariant is violated for arity %d", arity)
+			}
+
+			priorities := drainPriorities(pq)
+			sorted := slices.Clone(priorities)
+			slices.Sort(sorted)
+
+			if !min {
+				slices.Reverse(sorted)
+			}
+
+			if !slices.Equal(priorities, sorted) {
+				t.Errorf("Priorities are not dequeued in order for arity %d", arity)
+			}
+		}
+	}
+}
+
+func BenchmarkPQArity(b *testing.B) {
+	const size = 1 << 18
+	rng := rand.New(rand.NewSource(1))
+	priorities := make([]int32, size)
+
+	for i := range priorities {
+		priorities[i] = rng.Int31()
+	}
+
+	for _, arity := range []int{2, 4, 8} {
+		b.Run(fmt.Sprintf("Enqueue/%d", arity), func(b *testing.B) {
+			for b.Loop() {
+				pq := NewDAryPQueue[int](true, arity, size)
+
+				for i, priority := range priorities {
+					pq.Enqueue(i, priority)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("Dequeue/%d", arity), func(b *testing.B) {
+			pq := NewDAryPQueue[int](true, arity, size)
+
+			for i, priority := range priorities {
+				pq.Enqueue(i, priority)
+			}
+
+			for b.Loop() {
+				b.StopTimer()
+				clone := pq.Clone()
+				b.StartTimer()
+
+				for !clone.IsEmpty() {
+					clone.Dequeue()
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("Mixed/%d", arity), func(b *testing.B) {
+			for b.Loop() {
+				pq := NewDAryPQueue[int](true, arity, size)
+
+				for i, priority := range priorities {
+					pq.Enqueue(i, priority)
+
+					if i%3 == 2 {
+						pq.Dequeue()
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestPQSortedToSortedSlice(t *testing.T) {
+	rng := rand.New(rand.NewSource(11))
+
+	for _, arity := range []int{2, 4} {
+		pq := NewDAryPQueue[int](false, arity)
+
+		for i := range 300 {
+			pq.Enqueue(i, int32(rng.Intn(100)))
+		}
+
+		before := slices.Clone(pq.GetSlice())
+		sorted := pq.ToSortedSlice()
+
+		if len(sorted) != 300 {
+			t.Fatalf("Expected length 300, got %d instead", len(sorted))
+		}
+
+		if !slices.Equal(pq.GetSlice(), before) {
+			t.Error("Expected the queue to be left untouched")
+		}
+
+		priorities := make([]int32, len(sorted))
+
+		for i, item := range sorted {
+			priorities[i] = item.Priority
+		}
+
+		if !slices.Equal(priorities, drainPriorities(pq.Clone())) {
+			t.Errorf("Sorted traversal does not match dequeue order for arity %d", arity)
+		}
+
+		count := 0
+
+		for item := range pq.Sorted() {
+			if item.Priority != sorted[count].Priority {
+				t.Errorf("Expected priority %d, got %d instead", sorted[count].Priority, item.Priority)
+			}
+
+			count++
+
+			if count == 5 {
+				break
+			}
+		}
+
+		if count != 5 {
+			t.Errorf("Expected 5 items, got %d instead", count)
+		}
+	}
+
+	empty := NewMinPQueue[int]()
+
+	for range empty.Sorted() {
+		t.Error("Expected no items from an empty queue")
+	}
+
+	if len(empty.ToSortedSlice()) != 0 {
+		t.Error("Expected an empty slice, got a non-empty one instead")
+	}
+}
+
+func TestPQHook(t *testing.T) {
+	pq := NewMinPQueue[int]()
+	stats := instrument.NewStats()
+	pq.SetHook(stats)
+
+	for i := range 10 {
+		pq.Enqueue(i, int32(i))
+	}
+
+	pq.EnqueueAll(Item[int]{10, 10}, Item[int]{11, 11})
+	pq.Peek()
+
+	for range 5 {
+		pq.Dequeue()
+	}
+
+	pq.RemoveAt(0)
+	pq.Clear()
+
+	report := stats.Report()
+
+	if report.Counts[instrument.OpEnqueue] != 12 {
+		t.Errorf("Expected 12 enqueues, got %d instead", report.Counts[instrument.OpEnqueue])
+	}
+
+	if report.Counts[instrument.OpDequeue] != 5 || report.Counts[instrument.OpPeek] != 1 {
+		t.Errorf("Expected 5 dequeues and 1 peek, got %d and %d instead",
+			report.Counts[instrument.OpDequeue], report.Counts[instrument.OpPeek])
+	}
+
+	if report.Counts[instrument.OpRemove] != 1 || report.Counts[instrument.OpClear] != 1 {
+		t.Errorf("Expected 1 removal and 1 clear, got %d and %d instead",
+			report.Counts[instrument.OpRemove], report.Counts[instrument.OpClear])
+	}
+
+	if report.HighWaterMark != 12 || report.Size != 0 {
+		t.Errorf("Expected high-water mark 12 and size 0, got %d and %d instead", report.HighWaterMark, report.Size)
+	}
+
+	if pq.Clone().hook != nil {
+		t.Error("Expected the clone not to share the hook")
+	}
+}
+
+func BenchmarkPQHook(b *testing.B) {
+	for _, hooked := range []bool{false, true} {
+		b.Run(fmt.Sprintf("Hooked=%v", hooked), func(b *testing.B) {
+			pq := NewMinPQueue[int](1024)
+
+			if hooked {
+				pq.SetHook(instrument.NewStats())
+			}
+
+			for b.Loop() {
+				for i := range 1024 {
+					pq.Enqueue(i, int32(i*7919%1024))
+				}
+
+				for !pq.IsEmpty() {
+					pq.Dequeue()
+				}
+			}
+		})
+	}
+}
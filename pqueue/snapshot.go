@@ -0,0 +1,52 @@
+package pqueue
+
+import "slices"
+
+type Snapshot[T any] struct {
+	heap []Item[T]
+	compare func(int32, int32) bool
+	arity int
+	version uint64
+}
+
+func (p *PQueue[T]) Snapshot() Snapshot[T] {
+	p.shared = true
+	return Snapshot[T]{p.heap, p.compare, p.arity, p.version}
+}
+
+func (p *PQueue[T]) Restore(s Snapshot[T]) {
+	p.heap = s.heap
+	p.compare = s.compare
+	p.arity = s.arity
+	p.shared = true
+	p.version++
+}
+
+func (p PQueue[T]) Version() uint64 {
+	return p.version
+}
+
+func (s Snapshot[T]) Version() uint64 {
+	return s.version
+}
+
+func (s Snapshot[T]) Size() int {
+	return len(s.heap)
+}
+
+func (s Snapshot[T]) IsEmpty() bool {
+	return len(s.heap) == 0
+}
+
+func (s Snapshot[T]) PQueue() PQueue[T] {
+	return PQueue[T]{slices.Clone(s.heap), s.compare, s.arity, nil, 0, false}
+}
+
+func (p *PQueue[T]) mutate() {
+	if p.shared {
+		p.heap = slices.Clone(p.heap)
+		p.shared = false
+	}
+
+	p.version++
+}
@@ -0,0 +1,93 @@
+package pqueue
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	pq := NewMinPQueue[int](16)
+
+	for i := range 10 {
+		pq.Enqueue(i, int32((i*7)%10))
+	}
+
+	snapshot := pq.Snapshot()
+	before := drainPriorities(snapshot.PQueue())
+
+	if snapshot.Version() != pq.Version() || snapshot.Size() != 10 {
+		t.Errorf("Expected snapshot version %d and size 10, got %d and %d instead",
+			pq.Version(), snapshot.Version(), snapshot.Size())
+	}
+
+	pq.Dequeue()
+	pq.Enqueue(100, -1)
+	pq.Update(3, 3, 50)
+	pq.RemoveAt(5)
+
+	if pq.Version() == snapshot.Version() {
+		t.Error("Expected the version to change after mutations")
+	}
+
+	if after := drainPriorities(snapshot.PQueue()); !slices.Equal(before, after) {
+		t.Errorf("Expected the snapshot to be unchanged, got %v instead of %v", after, before)
+	}
+
+	version := pq.Version()
+	pq.Restore(snapshot)
+
+	if pq.Version() <= version {
+		t.Errorf("Expected restore to advance the version past %d, got %d instead", version, pq.Version())
+	}
+
+	if err := pq.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	pq.Clear()
+	pq.Enqueue(42, 0)
+
+	if restored := drainPriorities(snapshot.PQueue()); !slices.Equal(before, restored) {
+		t.Errorf("Expected the snapshot to survive Clear, got %v instead of %v", restored, before)
+	}
+
+	pq.Restore(snapshot)
+
+	if got := drainPriorities(pq); !slices.Equal(before, got) {
+		t.Errorf("Expected %v after restoring, got %v instead", before, got)
+	}
+}
+
+func TestSnapshotThroughHeapAdapter(t *testing.T) {
+	pq := FromItems(true, Item[string]{"a", 3}, Item[string]{"b", 1}, Item[string]{"c", 2})
+	snapshot := pq.Snapshot()
+	adapter, _ := pq.AsHeap()
+
+	adapter.Swap(0, 2)
+	adapter.Push(Item[string]{"d", 0})
+	adapter.Pop()
+
+	if got := drainPriorities(snapshot.PQueue()); !slices.Equal(got, []int32{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v instead", got)
+	}
+}
+
+func BenchmarkPQSnapshot(b *testing.B) {
+	pq := NewMinPQueue[int](1 << 16)
+
+	for i := range 1 << 16 {
+		pq.Enqueue(i, int32(i))
+	}
+
+	b.Run("Snapshot", func(b *testing.B) {
+		for range b.N {
+			_ = pq.Snapshot()
+		}
+	})
+
+	b.Run("Clone", func(b *testing.B) {
+		for range b.N {
+			_ = pq.Clone()
+		}
+	})
+}
@@ -0,0 +1,125 @@
+package pqueue
+
+import (
+	"iter"
+	"slices"
+)
+
+func Heapify[E any](slice []E, less func(E, E) bool) {
+	heapify(slice, DefaultArity, less)
+}
+
+func HeapSort[E any](slice []E, less func(E, E) bool) {
+	greater := reverse(less)
+	heapify(slice, DefaultArity, greater)
+
+	for end := len(slice) - 1; end > 0; end-- {
+		slice[0], slice[end] = slice[end], slice[0]
+		siftDown(slice[:end], 0, DefaultArity, greater)
+	}
+}
+
+func PartialSort[E any](slice []E, k int, less func(E, E) bool) {
+	k = min(max(k, 0), len(slice))
+
+	if k == 0 {
+		return
+	}
+
+	greater := reverse(less)
+	prefix := slice[:k]
+	heapify(prefix, DefaultArity, greater)
+
+	for i := k; i < len(slice); i++ {
+		if less(slice[i], prefix[0]) {
+			prefix[0], slice[i] = slice[i], prefix[0]
+			siftDown(prefix, 0, DefaultArity, greater)
+		}
+	}
+
+	HeapSort(prefix, less)
+}
+
+func NSmallest[E any](slice []E, n int, less func(E, E) bool) []E {
+	return NSmallestSeq(slices.Values(slice), n, less)
+}
+
+func NLargest[E any](slice []E, n int, less func(E, E) bool) []E {
+	return NSmallestSeq(slices.Values(slice), n, reverse(less))
+}
+
+func NSmallestSeq[E any](seq iter.Seq[E], n int, less func(E, E) bool) []E {
+	if n <= 0 {
+		return []E{}
+	}
+
+	greater := reverse(less)
+	heap := make([]E, 0, n)
+
+	for val := range seq {
+		if len(heap) < n {
+			heap = append(heap, val)
+			siftUp(heap, len(heap)-1, DefaultArity, greater)
+		} else if less(val, heap[0]) {
+			heap[0] = val
+			siftDown(heap, 0, DefaultArity, greater)
+		}
+	}
+
+	HeapSort(heap, less)
+	return heap
+}
+
+func NLargestSeq[E any](seq iter.Seq[E], n int, less func(E, E) bool) []E {
+	return NSmallestSeq(seq, n, reverse(less))
+}
+
+func reverse[E any](less func(E, E) bool) func(E, E) bool {
+	return func(lhs E, rhs E) bool { return less(rhs, lhs) }
+}
+
+func heapify[E any](heap []E, arity int, less func(E, E) bool) {
+	if len(heap) < 2 {
+		return
+	}
+
+	for i := (len(heap) - 2) / arity; i >= 0; i-- {
+		siftDown(heap, i, arity, less)
+	}
+}
+
+func siftUp[E any](heap []E, curr int, arity int, less func(E, E) bool) {
+	for curr > 0 {
+		parent := (curr - 1) / arity
+
+		if !less(heap[curr], heap[parent]) {
+			break
+		}
+
+		heap[curr], heap[parent] = heap[parent], heap[curr]
+		curr = parent
+	}
+}
+
+func siftDown[E any](heap []E, curr int, arity int, less func(E, E) bool) {
+	size := len(heap)
+	opt := curr
+
+	for {
+		first := curr*arity + 1
+		last := min(first+arity, size)
+
+		for child := first; child < last; child++ {
+			if less(heap[child], heap[opt]) {
+				opt = child
+			}
+		}
+
+		if curr == opt {
+			break
+		}
+
+		heap[curr], heap[opt] = heap[opt], heap[curr]
+		curr = opt
+	}
+}
@@ -0,0 +1,124 @@
+package pqueue
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func intLess(lhs int, rhs int) bool { return lhs < rhs }
+
+func randomInts(seed int64, n int) []int {
+	rng := rand.New(rand.NewSource(seed))
+	values := make([]int, n)
+
+	for i := range values {
+		values[i] = rng.Intn(1000)
+	}
+
+	return values
+}
+
+func TestHeapify(t *testing.T) {
+	values := randomInts(1, 257)
+	Heapify(values, intLess)
+
+	for i := 1; i < len(values); i++ {
+		if values[i] < values[(i-1)/2] {
+			t.Fatalf("Heap invariant is violated at index %d", i)
+		}
+	}
+
+	items := []Item[string]{{"c", 3}, {"a", 1}, {"d", 4}, {"b", 2}}
+	Heapify(items, func(lhs Item[string], rhs Item[string]) bool { return lhs.Priority > rhs.Priority })
+
+	if items[0].Value != "d" {
+		t.Errorf("Expected value d, got %s instead", items[0].Value)
+	}
+
+	var empty []int
+	Heapify(empty, intLess)
+}
+
+func TestHeapSort(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 10, 1000} {
+		values := randomInts(int64(n), n)
+		expected := slices.Clone(values)
+		slices.Sort(expected)
+		HeapSort(values, intLess)
+
+		if !slices.Equal(values, expected) {
+			t.Errorf("Expected a sorted slice of length %d", n)
+		}
+	}
+
+	words := []string{"pear", "fig", "apple", "kiwi"}
+	HeapSort(words, func(lhs string, rhs string) bool { return lhs > rhs })
+
+	if !slices.Equal(words, []string{"pear", "kiwi", "fig", "apple"}) {
+		t.Errorf("Expected %v, got %v instead", []string{"pear", "kiwi", "fig", "apple"}, words)
+	}
+}
+
+func TestPartialSort(t *testing.T) {
+	values := randomInts(5, 500)
+	expected := slices.Clone(values)
+	slices.Sort(expected)
+
+	for _, k := range []int{-1, 0, 1, 10, 499, 500, 600} {
+		clone := slices.Clone(values)
+		PartialSort(clone, k, intLess)
+		prefix := min(max(k, 0), len(values))
+
+		if !slices.Equal(clone[:prefix], expected[:prefix]) {
+			t.Errorf("Expected the first %d elements to be sorted", prefix)
+		}
+
+		rest := slices.Clone(clone)
+		slices.Sort(rest)
+
+		if !slices.Equal(rest, expected) {
+			t.Errorf("Expected a permutation of the input for k=%d", k)
+		}
+	}
+}
+
+func TestNSmallestNLargest(t *testing.T) {
+	values := []int{5, 1, 9, 3, 7, 3, 8}
+
+	if smallest := NSmallest(values, 3, intLess); !slices.Equal(smallest, []int{1, 3, 3}) {
+		t.Errorf("Expected %v, got %v instead", []int{1, 3, 3}, smallest)
+	}
+
+	if largest := NLargest(values, 2, intLess); !slices.Equal(largest, []int{9, 8}) {
+		t.Errorf("Expected %v, got %v instead", []int{9, 8}, largest)
+	}
+
+	if all := NSmallest(values, 100, intLess); !slices.Equal(all, []int{1, 3, 3, 5, 7, 8, 9}) {
+		t.Errorf("Expected %v, got %v instead", []int{1, 3, 3, 5, 7, 8, 9}, all)
+	}
+
+	if none := NLargest(values, 0, intLess); len(none) != 0 {
+		t.Errorf("Expected an empty slice, got %v instead", none)
+	}
+
+	if !slices.Equal(values, []int{5, 1, 9, 3, 7, 3, 8}) {
+		t.Error("Expected the input to be left untouched")
+	}
+
+	seq := func(yield func(int) bool) {
+		for i := range 1000 {
+			if !yield((i * 7919) % 1000) {
+				return
+			}
+		}
+	}
+
+	if smallest := NSmallestSeq(seq, 4, intLess); !slices.Equal(smallest, []int{0, 1, 2, 3}) {
+		t.Errorf("Expected %v, got %v instead", []int{0, 1, 2, 3}, smallest)
+	}
+
+	if largest := NLargestSeq(seq, 3, intLess); !slices.Equal(largest, []int{999, 998, 997}) {
+		t.Errorf("Expected %v, got %v instead", []int{999, 998, 997}, largest)
+	}
+}
@@ -0,0 +1,211 @@
+package pqueue
+
+import "time"
+
+type DuplicatePolicy int
+
+const (
+	KeepBetter DuplicatePolicy = iota
+	ReplaceExisting
+	RejectDuplicate
+)
+
+type uniqueEntry[K comparable, T any] struct {
+	key     K
+	value   T
+	version uint64
+}
+
+type uniqueSlot struct {
+	priority int32
+	version  uint64
+}
+
+// RateLimit is a token bucket: one token is added every Every, up to Burst.
+type RateLimit struct {
+	Burst int
+	Every time.Duration
+}
+
+type UniquePQueue[K comparable, T any] struct {
+	pq       PQueue[uniqueEntry[K, T]]
+	index    map[K]uniqueSlot
+	keyOf    func(T) K
+	policy   DuplicatePolicy
+	version  uint64
+	limit    RateLimit
+	tokens   int
+	refilled time.Time
+	clock    Clock
+}
+
+func NewUniquePQueue[T comparable](min bool, policy DuplicatePolicy) UniquePQueue[T, T] {
+	return NewUniquePQueueFunc(min, policy, func(value T) T { return value })
+}
+
+func NewUniquePQueueFunc[K comparable, T any](min bool, policy DuplicatePolicy, keyOf func(T) K) UniquePQueue[K, T] {
+	return UniquePQueue[K, T]{NewPQueue[uniqueEntry[K, T]](min), make(map[K]uniqueSlot), keyOf, policy, 0, RateLimit{}, 0, time.Time{}, nil}
+}
+
+// SetRateLimit caps how many enqueues are accepted over time. Duplicates the
+// policy rejects don't consume tokens. A limit with a non-positive Burst or
+// Every removes the cap.
+func (u *UniquePQueue[K, T]) SetRateLimit(limit RateLimit, clock ...Clock) {
+	u.clock = systemClock{}
+
+	if len(clock) > 0 && clock[0] != nil {
+		u.clock = clock[0]
+	}
+
+	u.limit = limit
+	u.tokens = max(limit.Burst, 0)
+	u.refilled = u.clock.Now()
+}
+
+// Enqueue reports false when the duplicate policy rejects value or the rate
+// limit has no tokens left.
+func (u *UniquePQueue[K, T]) Enqueue(value T, priority int32) bool {
+	key := u.keyOf(value)
+
+	if slot, found := u.index[key]; found {
+		switch u.policy {
+		case RejectDuplicate:
+			return false
+		case KeepBetter:
+			if !u.pq.compare(priority, slot.priority) {
+				return false
+			}
+		}
+	}
+
+	if u.isRateLimited() {
+		u.refill()
+
+		if u.tokens == 0 {
+			return false
+		}
+
+		u.tokens--
+	}
+
+	u.version++
+	u.index[key] = uniqueSlot{priority, u.version}
+	u.pq.Enqueue(uniqueEntry[K, T]{key, value, u.version}, priority)
+	u.compactIfSparse()
+	return true
+}
+
+func (u *UniquePQueue[K, T]) Dequeue() (Item[T], error) {
+	u.dropStale()
+	item, err := u.pq.Dequeue()
+
+	if err != nil {
+		return Item[T]{}, err
+	}
+
+	delete(u.index, item.Value.key)
+	return Item[T]{item.Value.value, item.Priority}, nil
+}
+
+func (u *UniquePQueue[K, T]) Peek() (Item[T], error) {
+	u.dropStale()
+	item, err := u.pq.Peek()
+
+	if err != nil {
+		return Item[T]{}, err
+	}
+
+	return Item[T]{item.Value.value, item.Priority}, nil
+}
+
+func (u UniquePQueue[K, T]) Contains(value T) bool {
+	_, found := u.index[u.keyOf(value)]
+	return found
+}
+
+func (u UniquePQueue[K, T]) ContainsKey(key K) bool {
+	_, found := u.index[key]
+	return found
+}
+
+func (u UniquePQueue[K, T]) PriorityOf(value T) (int32, bool) {
+	slot, found := u.index[u.keyOf(value)]
+	return slot.priority, found
+}
+
+func (u *UniquePQueue[K, T]) Remove(value T) bool {
+	return u.RemoveKey(u.keyOf(value))
+}
+
+func (u *UniquePQueue[K, T]) RemoveKey(key K) bool {
+	if _, found := u.index[key]; !found {
+		return false
+	}
+
+	delete(u.index, key)
+	u.compactIfSparse()
+	return true
+}
+
+func (u *UniquePQueue[K, T]) Clear() {
+	u.pq.Clear()
+	clear(u.index)
+}
+
+func (u UniquePQueue[K, T]) IsEmpty() bool {
+	return len(u.index) == 0
+}
+
+func (u UniquePQueue[K, T]) Size() int {
+	return len(u.index)
+}
+
+func (u UniquePQueue[K, T]) isRateLimited() bool {
+	return u.limit.Burst > 0 && u.limit.Every > 0
+}
+
+func (u *UniquePQueue[K, T]) refill() {
+	now := u.clock.Now()
+	earned := int(now.Sub(u.refilled) / u.limit.Every)
+
+	if earned <= 0 {
+		return
+	}
+
+	u.tokens = min(u.tokens+earned, u.limit.Burst)
+
+	if u.tokens == u.limit.Burst {
+		u.refilled = now
+	} else {
+		u.refilled = u.refilled.Add(time.Duration(earned) * u.limit.Every)
+	}
+}
+
+func (u UniquePQueue[K, T]) isLive(entry uniqueEntry[K, T]) bool {
+	slot, found := u.index[entry.key]
+	return found && slot.version == entry.version
+}
+
+func (u *UniquePQueue[K, T]) dropStale() {
+	for !u.pq.IsEmpty() && !u.isLive(u.pq.heap[0].Value) {
+		u.pq.Dequeue()
+	}
+}
+
+func (u *UniquePQueue[K, T]) compactIfSparse() {
+	if len(u.pq.heap) <= 2*len(u.index)+16 {
+		return
+	}
+
+	live := u.pq.heap[:0]
+
+	for _, item := range u.pq.heap {
+		if u.isLive(item.Value) {
+			live = append(live, item)
+		}
+	}
+
+	clear(u.pq.heap[len(live):])
+	u.pq.heap = live
+	u.pq.heapify()
+}
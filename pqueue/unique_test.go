@@ -0,0 +1,200 @@
+package pqueue
+
+import (
+	"testing"
+	"time"
+)
+
+type task struct {
+	ID      int
+	Payload string
+}
+
+func TestUniqueKeepBetter(t *testing.T) {
+	u := NewUniquePQueue[string](true, KeepBetter)
+
+	if !u.Enqueue("a", 5) {
+		t.Error("Expected the first enqueue to be accepted")
+	}
+
+	if u.Enqueue("a", 7) {
+		t.Error("Expected a worse duplicate to be ignored")
+	}
+
+	if !u.Enqueue("a", 2) {
+		t.Error("Expected a better duplicate to be accepted")
+	}
+
+	u.Enqueue("b", 3)
+
+	if u.Size() != 2 {
+		t.Errorf("Expected size 2, got %d instead", u.Size())
+	}
+
+	if priority, found := u.PriorityOf("a"); !found || priority != 2 {
+		t.Errorf("Expected priority 2, got %d (%v) instead", priority, found)
+	}
+
+	item, _ := u.Dequeue()
+
+	if item.Value != "a" || item.Priority != 2 {
+		t.Errorf("Expected (a:2), got (%s:%d) instead", item.Value, item.Priority)
+	}
+
+	item, _ = u.Dequeue()
+
+	if item.Value != "b" {
+		t.Errorf("Expected value b, got %s instead", item.Value)
+	}
+
+	if _, err := u.Dequeue(); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	if _, err := u.Peek(); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+}
+
+func TestUniqueReplaceAndReject(t *testing.T) {
+	replace := NewUniquePQueue[int](false, ReplaceExisting)
+	replace.Enqueue(1, 10)
+	replace.Enqueue(2, 5)
+
+	if !replace.Enqueue(1, 1) {
+		t.Error("Expected the duplicate to replace the existing item")
+	}
+
+	item, _ := replace.Peek()
+
+	if item.Value != 2 {
+		t.Errorf("Expected value 2, got %d instead", item.Value)
+	}
+
+	reject := NewUniquePQueue[int](false, RejectDuplicate)
+	reject.Enqueue(1, 1)
+
+	if reject.Enqueue(1, 100) {
+		t.Error("Expected the duplicate to be rejected")
+	}
+
+	item, _ = reject.Peek()
+
+	if item.Priority != 1 {
+		t.Errorf("Expected priority 1, got %d instead", item.Priority)
+	}
+}
+
+func TestUniqueRateLimit(t *testing.T) {
+	clock := &manualClock{time.Unix(0, 0)}
+	u := NewUniquePQueue[int](true, RejectDuplicate)
+	u.SetRateLimit(RateLimit{2, time.Second}, clock)
+
+	if !u.Enqueue(1, 1) || u.Enqueue(1, 0) || !u.Enqueue(2, 2) {
+		t.Error("Expected the burst to accept two distinct values")
+	}
+
+	if u.Enqueue(3, 3) {
+		t.Error("Expected the enqueue to be rate limited")
+	}
+
+	clock.Advance(1500 * time.Millisecond)
+
+	if !u.Enqueue(3, 3) || u.Enqueue(4, 4) {
+		t.Error("Expected exactly one token after 1.5s")
+	}
+
+	clock.Advance(500 * time.Millisecond)
+
+	if !u.Enqueue(4, 4) {
+		t.Error("Expected the partial interval to carry over")
+	}
+
+	clock.Advance(time.Hour)
+
+	if !u.Enqueue(5, 5) || !u.Enqueue(6, 6) || u.Enqueue(7, 7) {
+		t.Error("Expected the bucket to hold at most the burst")
+	}
+
+	u.SetRateLimit(RateLimit{})
+
+	for i := 10; i < 20; i++ {
+		if !u.Enqueue(i, int32(i)) {
+			t.Fatal("Expected no rate limit")
+		}
+	}
+
+	if u.Size() != 16 {
+		t.Errorf("Expected size 16, got %d instead", u.Size())
+	}
+}
+
+func TestUniqueKeyFuncContainsRemove(t *testing.T) {
+	u := NewUniquePQueueFunc(true, ReplaceExisting, func(t task) int { return t.ID })
+	u.Enqueue(task{1, "first"}, 3)
+	u.Enqueue(task{2, "second"}, 1)
+	u.Enqueue(task{1, "updated"}, 4)
+
+	if !u.Contains(task{1, "anything"}) || !u.ContainsKey(2) {
+		t.Error("Expected both tasks to be present")
+	}
+
+	if u.Contains(task{3, ""}) {
+		t.Error("Expected task 3 to be absent")
+	}
+
+	if !u.Remove(task{2, ""}) {
+		t.Error("Expected task 2 to be removed")
+	}
+
+	if u.Remove(task{2, ""}) || u.RemoveKey(42) {
+		t.Error("Expected removing an absent task to fail")
+	}
+
+	item, err := u.Dequeue()
+
+	if err != nil {
+		t.Fatalf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	if item.Value.Payload != "updated" || item.Priority != 4 {
+		t.Errorf("Expected (updated:4), got (%s:%d) instead", item.Value.Payload, item.Priority)
+	}
+
+	if !u.IsEmpty() {
+		t.Errorf("Expected size 0, got %d instead", u.Size())
+	}
+}
+
+func TestUniqueCompaction(t *testing.T) {
+	u := NewUniquePQueue[int](true, ReplaceExisting)
+
+	for round := range 50 {
+		for i := range 10 {
+			u.Enqueue(i, int32(round*10+i))
+		}
+	}
+
+	if u.Size() != 10 {
+		t.Errorf("Expected size 10, got %d instead", u.Size())
+	}
+
+	if len(u.pq.heap) > 2*u.Size()+16 {
+		t.Errorf("Expected stale entries to be compacted, got %d heap entries", len(u.pq.heap))
+	}
+
+	for i := range 10 {
+		item, _ := u.Dequeue()
+
+		if item.Value != i || item.Priority != int32(490+i) {
+			t.Errorf("Expected (%d:%d), got (%d:%d) instead", i, 490+i, item.Value, item.Priority)
+		}
+	}
+
+	u.Enqueue(1, 1)
+	u.Clear()
+
+	if !u.IsEmpty() || u.Contains(1) {
+		t.Error("Expected an empty queue")
+	}
+}
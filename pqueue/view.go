@@ -0,0 +1,120 @@
+package pqueue
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"time"
+
+	"github.com/XeniaPhe/xengods/instrument"
+)
+
+var indexOutOfRangeError error
+
+func init() {
+	indexOutOfRangeError = errors.New("index out of range")
+}
+
+type View[T any] struct {
+	pq *PQueue[T]
+}
+
+func (p *PQueue[T]) View() View[T] {
+	return View[T]{p}
+}
+
+func (v View[T]) At(index int) (Item[T], error) {
+	if index < 0 || index >= len(v.pq.heap) {
+		return Item[T]{}, indexOutOfRangeError
+	}
+
+	return v.pq.heap[index], nil
+}
+
+func (v View[T]) Peek() (Item[T], error) {
+	return v.pq.Peek()
+}
+
+func (v View[T]) All() iter.Seq2[int, Item[T]] {
+	return func(yield func(int, Item[T]) bool) {
+		for i, item := range v.pq.heap {
+			if !yield(i, item) {
+				return
+			}
+		}
+	}
+}
+
+func (v View[T]) Sorted() iter.Seq[Item[T]] {
+	return v.pq.Sorted()
+}
+
+func (v View[T]) Len() int {
+	return len(v.pq.heap)
+}
+
+func (v View[T]) IsEmpty() bool {
+	return len(v.pq.heap) == 0
+}
+
+func (p *PQueue[T]) Update(index int, value T, priority int32) error {
+	if index < 0 || index >= len(p.heap) {
+		return indexOutOfRangeError
+	}
+
+	p.mutate()
+	p.heap[index] = Item[T]{value, priority}
+	p.fix(index)
+	return nil
+}
+
+func (p *PQueue[T]) Fix(index int) error {
+	if index < 0 || index >= len(p.heap) {
+		return indexOutOfRangeError
+	}
+
+	p.mutate()
+	p.fix(index)
+	return nil
+}
+
+func (p *PQueue[T]) RemoveAt(index int) (Item[T], error) {
+	if p.hook != nil {
+		defer p.observe(instrument.OpRemove, time.Now())
+	}
+
+	size := len(p.heap)
+
+	if index < 0 || index >= size {
+		return Item[T]{}, indexOutOfRangeError
+	}
+
+	p.mutate()
+	res := p.heap[index]
+	p.heap[index] = p.heap[size-1]
+	p.heap = p.heap[:size-1]
+
+	if index < size-1 {
+		p.fix(index)
+	}
+
+	return res, nil
+}
+
+func (p PQueue[T]) Validate() error {
+	for i := 1; i < len(p.heap); i++ {
+		parent := (i - 1) / p.arity
+
+		if p.less(p.heap[i], p.heap[parent]) {
+			return fmt.Errorf("heap property violated at index %d: priority %d is ordered before its parent's %d",
+				i, p.heap[i].Priority, p.heap[parent].Priority)
+		}
+	}
+
+	return nil
+}
+
+func (p PQueue[T]) fix(index int) {
+	p.heapifyDown(index)
+	p.heapifyUp(index)
+}
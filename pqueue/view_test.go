@@ -0,0 +1,221 @@
+package pqueue
+
+import (
+	"testing"
+)
+
+func TestPQView(t *testing.T) {
+	pq := NewMinPQueue[string]()
+	pq.Enqueue("c", 3)
+	pq.Enqueue("a", 1)
+	pq.Enqueue("b", 2)
+
+	view := pq.View()
+
+	if view.Len() != 3 || view.IsEmpty() {
+		t.Errorf("Expected length 3, got %d instead", view.Len())
+	}
+
+	item, err := view.At(0)
+
+	if err != nil {
+		t.Errorf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	if item.Value != "a" {
+		t.Errorf("Expected value a, got %s instead", item.Value)
+	}
+
+	if _, err := view.At(3); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	if _, err := view.At(-1); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	peeked, _ := view.Peek()
+
+	if peeked != item {
+		t.Errorf("Expected %v, got %v instead", item, peeked)
+	}
+
+	count := 0
+
+	for i, item := range view.All() {
+		at, _ := view.At(i)
+
+		if at != item {
+			t.Errorf("Expected %v, got %v instead", at, item)
+		}
+
+		count++
+	}
+
+	if count != 3 {
+		t.Errorf("Expected 3 items, got %d instead", count)
+	}
+
+	expected := []string{"a", "b", "c"}
+	i := 0
+
+	for item := range view.Sorted() {
+		if item.Value != expected[i] {
+			t.Errorf("Expected value %s, got %s instead", expected[i], item.Value)
+		}
+
+		i++
+	}
+}
+
+func TestPQUpdateFixRemoveAt(t *testing.T) {
+	pq := NewMaxPQueue[int]()
+
+	for i := range 20 {
+		pq.Enqueue(i, int32(i))
+	}
+
+	if err := pq.Validate(); err != nil {
+		t.Errorf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	if err := pq.Update(pq.Size()-1, 100, 100); err != nil {
+		t.Errorf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	item, _ := pq.Peek()
+
+	if item.Value != 100 {
+		t.Errorf("Expected value 100, got %d instead", item.Value)
+	}
+
+	if err := pq.Update(0, -1, -1); err != nil {
+		t.Errorf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	if err := pq.Validate(); err != nil {
+		t.Errorf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	if err := pq.Update(20, 0, 0); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	pq.GetSlice()[3].Priority = 1000
+
+	if err := pq.Validate(); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	if err := pq.Fix(3); err != nil {
+		t.Errorf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	if err := pq.Validate(); err != nil {
+		t.Errorf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	if err := pq.Fix(-1); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	size := pq.Size()
+
+	for i := range 5 {
+		removed, err := pq.RemoveAt(pq.Size() / (i + 2))
+
+		if err != nil {
+			t.Errorf("Expected no error, got '%s' instead", err.Error())
+		}
+
+		if removed.Priority == 1000 {
+			t.Error("Expected the root to be kept")
+		}
+
+		if err := pq.Validate(); err != nil {
+			t.Errorf("Expected no error, got '%s' instead", err.Error())
+		}
+	}
+
+	if pq.Size() != size-5 {
+		t.Errorf("Expected size %d, got %d instead", size-5, pq.Size())
+	}
+
+	last, err := pq.RemoveAt(pq.Size() - 1)
+
+	if err != nil {
+		t.Errorf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	if _, err := pq.RemoveAt(pq.Size()); err == nil {
+		t.Errorf("Expected an error, got nothing after removing %v", last)
+	}
+}
+
+func TestPQInitializeIfNotReset(t *testing.T) {
+	pq := NewMinPQueue[int]()
+	pq.Enqueue(1, 1)
+	pq.Enqueue(2, 2)
+
+	pq.InitializeIfNot(false)
+
+	if pq.Size() != 2 {
+		t.Errorf("Expected size 2, got %d instead", pq.Size())
+	}
+
+	item, _ := pq.Peek()
+
+	if item.Priority != 1 {
+		t.Errorf("Expected priority 1, got %d instead", item.Priority)
+	}
+
+	pq.Reset(false)
+
+	if !pq.IsEmpty() {
+		t.Errorf("Expected size 0, got %d instead", pq.Size())
+	}
+
+	pq.Enqueue(1, 1)
+	pq.Enqueue(2, 2)
+	item, _ = pq.Peek()
+
+	if item.Priority != 2 {
+		t.Errorf("Expected priority 2, got %d instead", item.Priority)
+	}
+
+	quad := NewDAryPQueue[int](true, 4)
+	quad.Reset(false)
+
+	if quad.Arity() != 4 {
+		t.Errorf("Expected arity 4, got %d instead", quad.Arity())
+	}
+}
+
+func TestPQViewIsLive(t *testing.T) {
+	pq := NewMinPQueue[string]()
+	pq.Enqueue("a", 1)
+	pq.Enqueue("b", 2)
+	pq.Enqueue("c", 3)
+
+	view := pq.View()
+	pq.Dequeue()
+	pq.Dequeue()
+
+	if view.Len() != 1 {
+		t.Errorf("Expected length 1, got %d instead", view.Len())
+	}
+
+	if _, err := view.At(2); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	if peeked, _ := view.Peek(); peeked.Value != "c" {
+		t.Errorf("Expected c, got %s instead", peeked.Value)
+	}
+
+	pq.Enqueue("d", 0)
+
+	if top, _ := view.At(0); view.Len() != 2 || top.Value != "d" {
+		t.Errorf("Expected d on top of 2 items, got %s of %d instead", top.Value, view.Len())
+	}
+}
@@ -0,0 +1,69 @@
+package set
+
+import (
+	"encoding/json"
+)
+
+type Changeset[T comparable] struct {
+	Added   Set[T]
+	Removed Set[T]
+}
+
+type changesetJSON[T comparable] struct {
+	Added   []T `json:"added"`
+	Removed []T `json:"removed"`
+}
+
+func Diff[T comparable](before Set[T], after Set[T]) Changeset[T] {
+	return Changeset[T]{
+		Added:   after.Except(before),
+		Removed: before.Except(after),
+	}
+}
+
+func (s Set[T]) Apply(changes Changeset[T]) {
+	s.ExceptWith(changes.Removed)
+	s.UnionWith(changes.Added)
+}
+
+func (c Changeset[T]) IsEmpty() bool {
+	return c.Added.IsEmpty() && c.Removed.IsEmpty()
+}
+
+func (c Changeset[T]) Size() int {
+	return c.Added.Size() + c.Removed.Size()
+}
+
+func (c Changeset[T]) Clone() Changeset[T] {
+	return Changeset[T]{c.Added.Clone(), c.Removed.Clone()}
+}
+
+func (c Changeset[T]) Invert() Changeset[T] {
+	return Changeset[T]{c.Removed.Clone(), c.Added.Clone()}
+}
+
+func (c Changeset[T]) Compose(next Changeset[T]) Changeset[T] {
+	added := c.Added.Except(next.Removed)
+	added.UnionWith(next.Added.Except(c.Removed))
+
+	removed := c.Removed.Except(next.Added)
+	removed.UnionWith(next.Removed.Except(c.Added))
+
+	return Changeset[T]{added, removed}
+}
+
+func (c Changeset[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(changesetJSON[T]{c.Added.orderedSlice(), c.Removed.orderedSlice()})
+}
+
+func (c *Changeset[T]) UnmarshalJSON(data []byte) error {
+	var raw changesetJSON[T]
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	c.Added = Of(raw.Added...)
+	c.Removed = Of(raw.Removed...)
+	return nil
+}
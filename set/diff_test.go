@@ -0,0 +1,148 @@
+package set
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSetDiffApply(t *testing.T) {
+	before := Of(1, 2, 3, 4)
+	after := Of(3, 4, 5, 6, 7)
+
+	changes := Diff(before, after)
+
+	if changes.Added.Size() != 3 {
+		t.Errorf("Expected size 3, got %d instead", changes.Added.Size())
+	}
+
+	if !changes.Added.ContainsAll(5, 6, 7) {
+		t.Error("Set does not contain all the elements it should have contained")
+	}
+
+	if changes.Removed.Size() != 2 {
+		t.Errorf("Expected size 2, got %d instead", changes.Removed.Size())
+	}
+
+	if !changes.Removed.ContainsAll(1, 2) {
+		t.Error("Set does not contain all the elements it should have contained")
+	}
+
+	if changes.Size() != 5 {
+		t.Errorf("Expected size 5, got %d instead", changes.Size())
+	}
+
+	patched := before.Clone()
+	patched.Apply(changes)
+
+	if !patched.SetEquals(after) {
+		t.Errorf("Expected %v, got %v instead", after, patched)
+	}
+
+	if before.Size() != 4 {
+		t.Errorf("Expected size 4, got %d instead", before.Size())
+	}
+
+	if !Diff(after, after).IsEmpty() {
+		t.Error("Expected an empty changeset, got a non-empty one instead")
+	}
+}
+
+func TestSetChangesetInvert(t *testing.T) {
+	before := Of(1, 2, 3, 4)
+	after := Of(3, 4, 5, 6, 7)
+
+	changes := Diff(before, after)
+	inverse := changes.Invert()
+
+	if !inverse.Added.SetEquals(changes.Removed) {
+		t.Errorf("Expected %v, got %v instead", changes.Removed, inverse.Added)
+	}
+
+	if !inverse.Removed.SetEquals(changes.Added) {
+		t.Errorf("Expected %v, got %v instead", changes.Added, inverse.Removed)
+	}
+
+	patched := after.Clone()
+	patched.Apply(inverse)
+
+	if !patched.SetEquals(before) {
+		t.Errorf("Expected %v, got %v instead", before, patched)
+	}
+
+	inverse.Added.Add(100)
+
+	if changes.Removed.Contains(100) {
+		t.Error("Set contains an unexpected item: 100")
+	}
+}
+
+func TestSetChangesetCompose(t *testing.T) {
+	first := Of(1, 2, 3, 4)
+	second := Of(3, 4, 5, 6)
+	third := Of(1, 4, 6, 8)
+
+	composed := Diff(first, second).Compose(Diff(second, third))
+	expected := Diff(first, third)
+
+	if !composed.Added.SetEquals(expected.Added) {
+		t.Errorf("Expected %v, got %v instead", expected.Added, composed.Added)
+	}
+
+	if !composed.Removed.SetEquals(expected.Removed) {
+		t.Errorf("Expected %v, got %v instead", expected.Removed, composed.Removed)
+	}
+
+	patched := first.Clone()
+	patched.Apply(composed)
+
+	if !patched.SetEquals(third) {
+		t.Errorf("Expected %v, got %v instead", third, patched)
+	}
+
+	roundTrip := Diff(first, second).Compose(Diff(second, first))
+
+	if !roundTrip.IsEmpty() {
+		t.Errorf("Expected an empty changeset, got %v and %v instead", roundTrip.Added, roundTrip.Removed)
+	}
+}
+
+func TestSetChangesetJSON(t *testing.T) {
+	changes := Diff(Of("a", "b", "c"), Of("b", "c", "d", "e"))
+	data, err := json.Marshal(changes)
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if string(data) != `{"added":["d","e"],"removed":["a"]}` {
+		t.Errorf("Expected sorted elements, got %s instead", data)
+	}
+
+	var decoded Changeset[string]
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !decoded.Added.SetEquals(Of("d", "e")) {
+		t.Errorf("Expected %v, got %v instead", Of("d", "e"), decoded.Added)
+	}
+
+	if !decoded.Removed.SetEquals(Of("a")) {
+		t.Errorf("Expected %v, got %v instead", Of("a"), decoded.Removed)
+	}
+
+	if err := json.Unmarshal([]byte(`{"added":[1]}`), &decoded); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	var empty Changeset[int]
+
+	if err := json.Unmarshal([]byte(`{}`), &empty); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !empty.IsEmpty() || !empty.Added.IsInitialized() || !empty.Removed.IsInitialized() {
+		t.Error("Expected an empty initialized changeset")
+	}
+}
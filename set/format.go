@@ -0,0 +1,166 @@
+package set
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+func ToSortedSlice[T cmp.Ordered](s Set[T]) []T {
+	slice := s.ToSlice()
+	slices.Sort(slice)
+	return slice
+}
+
+func (s Set[T]) ToSliceFunc(compare func(T, T) int) []T {
+	slice := s.ToSlice()
+	slices.SortFunc(slice, compare)
+	return slice
+}
+
+func (s Set[T]) Format(state fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && state.Flag('#'):
+		prefix := fmt.Sprintf("set.Of[%s](", reflect.TypeFor[T]())
+		pad(state, joinElements(prefix, s.orderedSlice(), "%#v", ")"))
+	case verb == 'v' && state.Flag('+'):
+		pad(state, joinElements("Set{", s.ToSlice(), "%+v", "}"))
+	default:
+		pad(state, joinElements("Set{", s.orderedSlice(), elementFormat(state, verb), "}"))
+	}
+}
+
+// The verb, flags and precision apply to each element, while the width pads the
+// set as a whole. %s is formatted like %v so that it works for any element type.
+func elementFormat(state fmt.State, verb rune) string {
+	var builder strings.Builder
+	builder.WriteByte('%')
+
+	for _, flag := range "+# 0" {
+		if state.Flag(int(flag)) {
+			builder.WriteRune(flag)
+		}
+	}
+
+	if precision, found := state.Precision(); found {
+		fmt.Fprintf(&builder, ".%d", precision)
+	}
+
+	if verb == 's' {
+		verb = 'v'
+	}
+
+	builder.WriteRune(verb)
+	return builder.String()
+}
+
+func pad(state fmt.State, formatted string) {
+	width, _ := state.Width()
+
+	if state.Flag('-') {
+		fmt.Fprintf(state, "%-*s", width, formatted)
+	} else {
+		fmt.Fprintf(state, "%*s", width, formatted)
+	}
+}
+
+func (s Set[T]) orderedSlice() []T {
+	slice := s.ToSlice()
+
+	if !sortBuiltin(slice) {
+		sortByKind(slice)
+	}
+
+	return slice
+}
+
+func sortBuiltin(values any) bool {
+	switch values := values.(type) {
+	case []int:
+		slices.Sort(values)
+	case []int8:
+		slices.Sort(values)
+	case []int16:
+		slices.Sort(values)
+	case []int32:
+		slices.Sort(values)
+	case []int64:
+		slices.Sort(values)
+	case []uint:
+		slices.Sort(values)
+	case []uint8:
+		slices.Sort(values)
+	case []uint16:
+		slices.Sort(values)
+	case []uint32:
+		slices.Sort(values)
+	case []uint64:
+		slices.Sort(values)
+	case []uintptr:
+		slices.Sort(values)
+	case []float32:
+		slices.Sort(values)
+	case []float64:
+		slices.Sort(values)
+	case []string:
+		slices.Sort(values)
+	default:
+		return false
+	}
+
+	return true
+}
+
+// Named types with an ordered underlying kind don't match sortBuiltin, so their
+// sort keys are extracted through reflection once per element.
+func sortByKind[T any](values []T) {
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		sortByKey(values, reflect.Value.Int)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		sortByKey(values, reflect.Value.Uint)
+	case reflect.Float32, reflect.Float64:
+		sortByKey(values, reflect.Value.Float)
+	case reflect.String:
+		sortByKey(values, reflect.Value.String)
+	}
+}
+
+func sortByKey[T any, K cmp.Ordered](values []T, key func(reflect.Value) K) {
+	type keyed struct {
+		key   K
+		value T
+	}
+
+	pairs := make([]keyed, len(values))
+
+	for i, val := range values {
+		pairs[i] = keyed{key(reflect.ValueOf(val)), val}
+	}
+
+	slices.SortFunc(pairs, func(lhs keyed, rhs keyed) int {
+		return cmp.Compare(lhs.key, rhs.key)
+	})
+
+	for i, pair := range pairs {
+		values[i] = pair.value
+	}
+}
+
+func joinElements[T any](prefix string, values []T, format string, suffix string) string {
+	var builder strings.Builder
+	builder.WriteString(prefix)
+
+	for i, val := range values {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+
+		builder.WriteString(fmt.Sprintf(format, val))
+	}
+
+	builder.WriteString(suffix)
+	return builder.String()
+}
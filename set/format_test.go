@@ -0,0 +1,145 @@
+package set
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type point struct {
+	X int
+	Y int
+}
+
+type label string
+
+func TestSetToSortedSlice(t *testing.T) {
+	set := Of(5, 3, 9, 1, 7)
+	sorted := ToSortedSlice(set)
+
+	if !slices.Equal(sorted, []int{1, 3, 5, 7, 9}) {
+		t.Errorf("Expected %v, got %v instead", []int{1, 3, 5, 7, 9}, sorted)
+	}
+
+	words := ToSortedSlice(Of("pear", "apple", "fig"))
+
+	if !slices.Equal(words, []string{"apple", "fig", "pear"}) {
+		t.Errorf("Expected %v, got %v instead", []string{"apple", "fig", "pear"}, words)
+	}
+
+	if len(ToSortedSlice(New[int]())) != 0 {
+		t.Error("Expected an empty slice, got a non-empty one instead")
+	}
+}
+
+func TestSetToSliceFunc(t *testing.T) {
+	set := Of(point{2, 1}, point{1, 5}, point{1, 2})
+	sorted := set.ToSliceFunc(func(lhs point, rhs point) int {
+		if lhs.X != rhs.X {
+			return lhs.X - rhs.X
+		}
+
+		return lhs.Y - rhs.Y
+	})
+
+	expected := []point{{1, 2}, {1, 5}, {2, 1}}
+
+	if !slices.Equal(sorted, expected) {
+		t.Errorf("Expected %v, got %v instead", expected, sorted)
+	}
+}
+
+func TestSetDeterministicString(t *testing.T) {
+	set := Of(42, -7, 3, 15, 0, 8)
+
+	for range 20 {
+		if set.String() != "Set{-7, 0, 3, 8, 15, 42}" {
+			t.Fatalf("Expected '%s', got '%s' instead", "Set{-7, 0, 3, 8, 15, 42}", set.String())
+		}
+	}
+
+	floats := Of(2.5, -1.0, 0.25)
+
+	if floats.String() != "Set{-1, 0.25, 2.5}" {
+		t.Errorf("Expected '%s', got '%s' instead", "Set{-1, 0.25, 2.5}", floats.String())
+	}
+
+	labels := Of[label]("b", "c", "a")
+
+	if labels.String() != "Set{a, b, c}" {
+		t.Errorf("Expected '%s', got '%s' instead", "Set{a, b, c}", labels.String())
+	}
+
+	unsigned := Of[uint8](200, 3, 17)
+
+	if unsigned.String() != "Set{3, 17, 200}" {
+		t.Errorf("Expected '%s', got '%s' instead", "Set{3, 17, 200}", unsigned.String())
+	}
+
+	points := Of(point{1, 2})
+
+	if points.String() != "Set{{1 2}}" {
+		t.Errorf("Expected '%s', got '%s' instead", "Set{{1 2}}", points.String())
+	}
+
+	if New[int]().String() != "Set{}" {
+		t.Errorf("Expected '%s', got '%s' instead", "Set{}", New[int]().String())
+	}
+}
+
+func TestSetFormat(t *testing.T) {
+	set := Of(3, 1, 2)
+
+	if str := fmt.Sprintf("%v", set); str != "Set{1, 2, 3}" {
+		t.Errorf("Expected '%s', got '%s' instead", "Set{1, 2, 3}", str)
+	}
+
+	if str := fmt.Sprintf("%s", set); str != "Set{1, 2, 3}" {
+		t.Errorf("Expected '%s', got '%s' instead", "Set{1, 2, 3}", str)
+	}
+
+	if str := fmt.Sprintf("%#v", set); str != "set.Of[int](1, 2, 3)" {
+		t.Errorf("Expected '%s', got '%s' instead", "set.Of[int](1, 2, 3)", str)
+	}
+
+	if str := fmt.Sprintf("%#v", Of("b", "a")); str != `set.Of[string]("a", "b")` {
+		t.Errorf("Expected '%s', got '%s' instead", `set.Of[string]("a", "b")`, str)
+	}
+
+	unsorted := fmt.Sprintf("%+v", set)
+
+	if !strings.HasPrefix(unsorted, "Set{") || !strings.HasSuffix(unsorted, "}") || len(unsorted) != len("Set{1, 2, 3}") {
+		t.Errorf("Unexpected unsorted format: '%s'", unsorted)
+	}
+
+	for _, digit := range []string{"1", "2", "3"} {
+		if !strings.Contains(unsorted, digit) {
+			t.Errorf("Expected '%s' to contain %s", unsorted, digit)
+		}
+	}
+
+	if str := fmt.Sprintf("%q", Of("b", "a")); str != `Set{"a", "b"}` {
+		t.Errorf("Expected '%s', got '%s' instead", `Set{"a", "b"}`, str)
+	}
+
+	if str := fmt.Sprintf("%14v|%-14s|", set, set); str != "  Set{1, 2, 3}|Set{1, 2, 3}  |" {
+		t.Errorf("Expected '%s', got '%s' instead", "  Set{1, 2, 3}|Set{1, 2, 3}  |", str)
+	}
+
+	if str := fmt.Sprintf("%#22v", set); str != "  set.Of[int](1, 2, 3)" {
+		t.Errorf("Expected '%s', got '%s' instead", "  set.Of[int](1, 2, 3)", str)
+	}
+
+	if str := fmt.Sprintf("%d", set); str != "Set{1, 2, 3}" {
+		t.Errorf("Expected '%s', got '%s' instead", "Set{1, 2, 3}", str)
+	}
+
+	if str := fmt.Sprintf("%x|%#X", Of(10, 255), Of(10, 255)); str != "Set{a, ff}|Set{0XA, 0XFF}" {
+		t.Errorf("Expected '%s', got '%s' instead", "Set{a, ff}|Set{0XA, 0XFF}", str)
+	}
+
+	if str := fmt.Sprintf("%.2f", Of(0.5, 1.25)); str != "Set{0.50, 1.25}" {
+		t.Errorf("Expected '%s', got '%s' instead", "Set{0.50, 1.25}", str)
+	}
+}
@@ -0,0 +1,247 @@
+package set
+
+import (
+	"time"
+
+	"github.com/XeniaPhe/xengods/instrument"
+)
+
+type EventKind int
+
+const (
+	EventAdded EventKind = iota
+	EventRemoved
+	EventCleared
+	EventUnion
+	EventIntersection
+	EventExcept
+	EventSymmetricExcept
+	EventBatch
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventAdded:
+		return "Added"
+	case EventRemoved:
+		return "Removed"
+	case EventCleared:
+		return "Cleared"
+	case EventUnion:
+		return "Union"
+	case EventIntersection:
+		return "Intersection"
+	case EventExcept:
+		return "Except"
+	case EventSymmetricExcept:
+		return "SymmetricExcept"
+	case EventBatch:
+		return "Batch"
+	default:
+		return "Unknown"
+	}
+}
+
+type Event[T comparable] struct {
+	Kind EventKind
+	Changeset[T]
+}
+
+type Listener[T comparable] func(Event[T])
+
+type subscription[T comparable] struct {
+	id       int
+	listener Listener[T]
+}
+
+type Observable[T comparable] struct {
+	set        Set[T]
+	listeners  []subscription[T]
+	nextID     int
+	batchDepth int
+	pending    Changeset[T]
+}
+
+func NewObservable[T comparable](size ...int) *Observable[T] {
+	return &Observable[T]{set: New[T](size...)}
+}
+
+// Observe shares set's storage instead of copying it, so changes made through
+// the observable are visible in set. Changes made to set directly are not
+// reported to listeners.
+func Observe[T comparable](set Set[T]) *Observable[T] {
+	set.InitializeIfNot()
+	return &Observable[T]{set: set}
+}
+
+func (o *Observable[T]) Subscribe(listener Listener[T]) (unsubscribe func()) {
+	id := o.nextID
+	o.nextID++
+	o.listeners = append(o.listeners, subscription[T]{id, listener})
+
+	return func() {
+		for i, sub := range o.listeners {
+			if sub.id == id {
+				o.listeners = append(o.listeners[:i:i], o.listeners[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Events are sent synchronously from the mutating call, so ch must be buffered
+// or drained by another goroutine; otherwise the mutation blocks forever.
+func (o *Observable[T]) SubscribeChan(ch chan<- Event[T]) (unsubscribe func()) {
+	return o.Subscribe(func(event Event[T]) {
+		ch <- event
+	})
+}
+
+func (o *Observable[T]) Batch(fn func()) {
+	if o.batchDepth == 0 {
+		o.pending = Changeset[T]{New[T](), New[T]()}
+	}
+
+	o.batchDepth++
+
+	defer func() {
+		o.batchDepth--
+
+		if o.batchDepth == 0 {
+			pending := o.pending
+			o.pending = Changeset[T]{}
+			o.notify(EventBatch, pending)
+		}
+	}()
+
+	fn()
+}
+
+func (o *Observable[T]) Snapshot() Set[T] {
+	return o.set.Clone()
+}
+
+func (o *Observable[T]) Size() int {
+	return o.set.Size()
+}
+
+func (o *Observable[T]) IsEmpty() bool {
+	return o.set.IsEmpty()
+}
+
+func (o *Observable[T]) Contains(value T) bool {
+	return o.set.Contains(value)
+}
+
+func (o *Observable[T]) ContainsSome(values ...T) bool {
+	return o.set.ContainsSome(values...)
+}
+
+func (o *Observable[T]) ContainsAll(values ...T) bool {
+	return o.set.ContainsAll(values...)
+}
+
+func (o *Observable[T]) ToSlice() []T {
+	return o.set.ToSlice()
+}
+
+func (o *Observable[T]) String() string {
+	return o.set.String()
+}
+
+func (o *Observable[T]) Add(value T) {
+	if o.set.Contains(value) {
+		return
+	}
+
+	o.set.Add(value)
+	o.emit(EventAdded, Changeset[T]{Of(value), New[T]()})
+}
+
+func (o *Observable[T]) Remove(value T) {
+	if !o.set.Contains(value) {
+		return
+	}
+
+	o.set.Remove(value)
+	o.emit(EventRemoved, Changeset[T]{New[T](), Of(value)})
+}
+
+func (o *Observable[T]) Clear() {
+	if o.set.IsEmpty() {
+		return
+	}
+
+	if o.set.hook != nil {
+		defer o.set.observe(instrument.OpClear, time.Now())
+	}
+
+	removed := o.set.Clone()
+	clear(o.set.set)
+	o.emit(EventCleared, Changeset[T]{New[T](), removed})
+}
+
+func (o *Observable[T]) UnionWith(other Set[T]) {
+	added := other.Except(o.set)
+	o.set.UnionWith(added)
+	o.emit(EventUnion, Changeset[T]{added, New[T]()})
+}
+
+func (o *Observable[T]) IntersectWith(other Set[T]) {
+	removed := o.set.Except(other)
+	o.set.ExceptWith(removed)
+	o.emit(EventIntersection, Changeset[T]{New[T](), removed})
+}
+
+func (o *Observable[T]) ExceptWith(other Set[T]) {
+	removed := o.set.Intersection(other)
+	o.set.ExceptWith(removed)
+	o.emit(EventExcept, Changeset[T]{New[T](), removed})
+}
+
+func (o *Observable[T]) SymmetricExceptWith(other Set[T]) {
+	changes := Changeset[T]{other.Except(o.set), o.set.Intersection(other)}
+	o.set.Apply(changes)
+	o.emit(EventSymmetricExcept, changes)
+}
+
+func (o *Observable[T]) emit(kind EventKind, changes Changeset[T]) {
+	if o.batchDepth > 0 {
+		o.accumulate(changes)
+		return
+	}
+
+	o.notify(kind, changes)
+}
+
+// Composes changes into the pending batch in place, so a batch costs time
+// proportional to its changes rather than to what it has accumulated so far.
+func (o *Observable[T]) accumulate(changes Changeset[T]) {
+	for val := range changes.Added.set {
+		if _, found := o.pending.Removed.set[val]; found {
+			delete(o.pending.Removed.set, val)
+		} else {
+			o.pending.Added.set[val] = struct{}{}
+		}
+	}
+
+	for val := range changes.Removed.set {
+		if _, found := o.pending.Added.set[val]; found {
+			delete(o.pending.Added.set, val)
+		} else {
+			o.pending.Removed.set[val] = struct{}{}
+		}
+	}
+}
+
+func (o *Observable[T]) notify(kind EventKind, changes Changeset[T]) {
+	if changes.IsEmpty() {
+		return
+	}
+
+	event := Event[T]{kind, changes}
+
+	for _, sub := range o.listeners {
+		sub.listener(event)
+	}
+}
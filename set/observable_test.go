@@ -0,0 +1,225 @@
+package set
+
+import "testing"
+
+func TestObservableAddRemoveClear(t *testing.T) {
+	o := NewObservable[int]()
+	var events []Event[int]
+
+	o.Subscribe(func(event Event[int]) {
+		events = append(events, event)
+	})
+
+	o.Add(1)
+	o.Add(2)
+	o.Add(2)
+	o.Remove(1)
+	o.Remove(5)
+
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 events, got %d instead", len(events))
+	}
+
+	if events[0].Kind != EventAdded || !events[0].Added.SetEquals(Of(1)) || !events[0].Removed.IsEmpty() {
+		t.Errorf("Unexpected event: %v %v %v", events[0].Kind, events[0].Added, events[0].Removed)
+	}
+
+	if events[1].Kind != EventAdded || !events[1].Added.SetEquals(Of(2)) {
+		t.Errorf("Unexpected event: %v %v %v", events[1].Kind, events[1].Added, events[1].Removed)
+	}
+
+	if events[2].Kind != EventRemoved || !events[2].Removed.SetEquals(Of(1)) || !events[2].Added.IsEmpty() {
+		t.Errorf("Unexpected event: %v %v %v", events[2].Kind, events[2].Added, events[2].Removed)
+	}
+
+	o.Add(3)
+	events = nil
+	o.Clear()
+
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d instead", len(events))
+	}
+
+	if events[0].Kind != EventCleared || !events[0].Removed.SetEquals(Of(2, 3)) {
+		t.Errorf("Unexpected event: %v %v %v", events[0].Kind, events[0].Added, events[0].Removed)
+	}
+
+	if !o.IsEmpty() {
+		t.Errorf("Expected size 0, got %d instead", o.Size())
+	}
+
+	o.Clear()
+
+	if len(events) != 1 {
+		t.Errorf("Expected 1 event, got %d instead", len(events))
+	}
+}
+
+func TestObservableSetOperations(t *testing.T) {
+	o := Observe(Of(1, 2, 3, 4))
+	var events []Event[int]
+
+	o.Subscribe(func(event Event[int]) {
+		events = append(events, event)
+	})
+
+	o.UnionWith(Of(3, 4, 5, 6))
+	o.IntersectWith(Of(2, 3, 4, 5, 6, 7))
+	o.ExceptWith(Of(2, 9))
+	o.SymmetricExceptWith(Of(3, 10))
+	o.UnionWith(Of(4, 5))
+
+	if len(events) != 4 {
+		t.Fatalf("Expected 4 events, got %d instead", len(events))
+	}
+
+	expected := []struct {
+		kind    EventKind
+		added   Set[int]
+		removed Set[int]
+	}{
+		{EventUnion, Of(5, 6), New[int]()},
+		{EventIntersection, New[int](), Of(1)},
+		{EventExcept, New[int](), Of(2)},
+		{EventSymmetricExcept, Of(10), Of(3)},
+	}
+
+	for i, exp := range expected {
+		event := events[i]
+
+		if event.Kind != exp.kind {
+			t.Errorf("Expected %v, got %v instead", exp.kind, event.Kind)
+		}
+
+		if !event.Added.SetEquals(exp.added) {
+			t.Errorf("Expected %v, got %v instead", exp.added, event.Added)
+		}
+
+		if !event.Removed.SetEquals(exp.removed) {
+			t.Errorf("Expected %v, got %v instead", exp.removed, event.Removed)
+		}
+	}
+
+	if !o.Snapshot().SetEquals(Of(4, 5, 6, 10)) {
+		t.Errorf("Expected %v, got %v instead", Of(4, 5, 6, 10), o)
+	}
+}
+
+func TestObservableSharesStorage(t *testing.T) {
+	base := Of(1, 2, 3)
+	o := Observe(base)
+	var cleared Set[int]
+
+	o.Subscribe(func(event Event[int]) {
+		if event.Kind == EventCleared {
+			cleared = event.Removed
+		}
+	})
+
+	o.Clear()
+
+	if !base.IsEmpty() {
+		t.Errorf("Expected the base set to be cleared, got %v instead", base)
+	}
+
+	if !cleared.SetEquals(Of(1, 2, 3)) {
+		t.Errorf("Expected %v to be removed, got %v instead", Of(1, 2, 3), cleared)
+	}
+
+	o.Add(7)
+
+	if !base.SetEquals(Of(7)) {
+		t.Errorf("Expected %v, got %v instead", Of(7), base)
+	}
+}
+
+func TestObservableUnsubscribe(t *testing.T) {
+	o := NewObservable[string]()
+	first, second := 0, 0
+
+	unsubscribeFirst := o.Subscribe(func(Event[string]) { first++ })
+	o.Subscribe(func(Event[string]) { second++ })
+
+	o.Add("a")
+	unsubscribeFirst()
+	o.Add("b")
+	unsubscribeFirst()
+
+	if first != 1 {
+		t.Errorf("Expected 1 notification, got %d instead", first)
+	}
+
+	if second != 2 {
+		t.Errorf("Expected 2 notifications, got %d instead", second)
+	}
+}
+
+func TestObservableSubscribeChan(t *testing.T) {
+	o := NewObservable[int]()
+	ch := make(chan Event[int], 4)
+	unsubscribe := o.SubscribeChan(ch)
+
+	o.Add(1)
+	o.Remove(1)
+	unsubscribe()
+	o.Add(2)
+
+	if len(ch) != 2 {
+		t.Fatalf("Expected 2 events, got %d instead", len(ch))
+	}
+
+	if event := <-ch; event.Kind != EventAdded {
+		t.Errorf("Expected %v, got %v instead", EventAdded, event.Kind)
+	}
+
+	if event := <-ch; event.Kind != EventRemoved {
+		t.Errorf("Expected %v, got %v instead", EventRemoved, event.Kind)
+	}
+}
+
+func TestObservableBatch(t *testing.T) {
+	o := Observe(Of(1, 2, 3))
+	var events []Event[int]
+
+	o.Subscribe(func(event Event[int]) {
+		events = append(events, event)
+	})
+
+	o.Batch(func() {
+		o.Add(4)
+		o.Add(5)
+		o.Remove(1)
+		o.Remove(4)
+
+		o.Batch(func() {
+			o.Add(1)
+			o.Add(6)
+			o.ExceptWith(Of(2))
+		})
+	})
+
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d instead", len(events))
+	}
+
+	if events[0].Kind != EventBatch {
+		t.Errorf("Expected %v, got %v instead", EventBatch, events[0].Kind)
+	}
+
+	if !events[0].Added.SetEquals(Of(5, 6)) {
+		t.Errorf("Expected %v, got %v instead", Of(5, 6), events[0].Added)
+	}
+
+	if !events[0].Removed.SetEquals(Of(2)) {
+		t.Errorf("Expected %v, got %v instead", Of(2), events[0].Removed)
+	}
+
+	o.Batch(func() {
+		o.Add(10)
+		o.Remove(10)
+	})
+
+	if len(events) != 1 {
+		t.Errorf("Expected 1 event, got %d instead", len(events))
+	}
+}
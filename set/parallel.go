@@ -0,0 +1,131 @@
+package set
+
+import (
+	"runtime"
+	"sync"
+)
+
+const DefaultParallelThreshold = 1 << 16
+
+func (s Set[T]) Filter(predicate func(T) bool) Set[T] {
+	filtered := New[T](len(s.set) / 2)
+
+	for val := range s.set {
+		if predicate(val) {
+			filtered.set[val] = struct{}{}
+		}
+	}
+
+	return filtered
+}
+
+func (s Set[T]) ParallelUnion(other Set[T], threshold ...int) Set[T] {
+	smaller, bigger := orderBySize(s, other)
+
+	if len(smaller.set) < parallelThreshold(threshold) {
+		return s.Union(other)
+	}
+
+	missing := smaller.parallelCollect(func(val T) bool {
+		return !bigger.Contains(val)
+	})
+
+	union := New[T](len(bigger.set) + len(missing))
+
+	for val := range bigger.set {
+		union.set[val] = struct{}{}
+	}
+
+	for _, val := range missing {
+		union.set[val] = struct{}{}
+	}
+
+	return union
+}
+
+func (s Set[T]) ParallelIntersection(other Set[T], threshold ...int) Set[T] {
+	smaller, bigger := orderBySize(s, other)
+
+	if len(smaller.set) < parallelThreshold(threshold) {
+		return s.Intersection(other)
+	}
+
+	return Of(smaller.parallelCollect(bigger.Contains)...)
+}
+
+func (s Set[T]) ParallelExcept(other Set[T], threshold ...int) Set[T] {
+	if len(s.set) < parallelThreshold(threshold) {
+		return s.Except(other)
+	}
+
+	return Of(s.parallelCollect(func(val T) bool {
+		return !other.Contains(val)
+	})...)
+}
+
+func (s Set[T]) ParallelFilter(predicate func(T) bool, threshold ...int) Set[T] {
+	if len(s.set) < parallelThreshold(threshold) {
+		return s.Filter(predicate)
+	}
+
+	return Of(s.parallelCollect(predicate)...)
+}
+
+func (s Set[T]) parallelCollect(keep func(T) bool) []T {
+	values := s.ToSlice()
+	workers := min(runtime.GOMAXPROCS(0), len(values))
+
+	if workers <= 1 {
+		return collect(values, keep)
+	}
+
+	chunkSize := (len(values) + workers - 1) / workers
+	results := make([][]T, workers)
+	var wg sync.WaitGroup
+
+	for w := range workers {
+		start := min(w*chunkSize, len(values))
+		end := min(start+chunkSize, len(values))
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[w] = collect(values[start:end], keep)
+		}()
+	}
+
+	wg.Wait()
+	total := 0
+
+	for _, result := range results {
+		total += len(result)
+	}
+
+	merged := make([]T, 0, total)
+
+	for _, result := range results {
+		merged = append(merged, result...)
+	}
+
+	return merged
+}
+
+func collect[T any](values []T, keep func(T) bool) []T {
+	kept := make([]T, 0, len(values)/2)
+
+	for _, val := range values {
+		if keep(val) {
+			kept = append(kept, val)
+		}
+	}
+
+	return kept
+}
+
+func parallelThreshold(threshold []int) int {
+	if len(threshold) > 0 {
+		return threshold[0]
+	}
+
+	return DefaultParallelThreshold
+}
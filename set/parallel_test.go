@@ -0,0 +1,134 @@
+package set
+
+import (
+	"fmt"
+	"testing"
+)
+
+func rangeSet(from int, to int) Set[int] {
+	set := New[int](to - from)
+
+	for i := from; i < to; i++ {
+		set.Add(i)
+	}
+
+	return set
+}
+
+func TestSetFilter(t *testing.T) {
+	set := Of(1, 2, 3, 4, 5, 6)
+	even := set.Filter(func(val int) bool { return val%2 == 0 })
+
+	if !even.SetEquals(Of(2, 4, 6)) {
+		t.Errorf("Expected %v, got %v instead", Of(2, 4, 6), even)
+	}
+
+	if set.Size() != 6 {
+		t.Errorf("Expected size 6, got %d instead", set.Size())
+	}
+}
+
+func TestSetParallelOperations(t *testing.T) {
+	set1 := rangeSet(0, 10000)
+	set2 := rangeSet(5000, 20000)
+	isOdd := func(val int) bool { return val%2 == 1 }
+
+	for _, threshold := range []int{0, 1, DefaultParallelThreshold} {
+		union := set1.ParallelUnion(set2, threshold)
+
+		if !union.SetEquals(set1.Union(set2)) {
+			t.Errorf("Threshold %d: expected size %d, got %d instead", threshold, set1.Union(set2).Size(), union.Size())
+		}
+
+		intersection := set1.ParallelIntersection(set2, threshold)
+
+		if !intersection.SetEquals(set1.Intersection(set2)) {
+			t.Errorf("Threshold %d: expected size %d, got %d instead", threshold, set1.Intersection(set2).Size(), intersection.Size())
+		}
+
+		except := set2.ParallelExcept(set1, threshold)
+
+		if !except.SetEquals(set2.Except(set1)) {
+			t.Errorf("Threshold %d: expected size %d, got %d instead", threshold, set2.Except(set1).Size(), except.Size())
+		}
+
+		filtered := set1.ParallelFilter(isOdd, threshold)
+
+		if !filtered.SetEquals(set1.Filter(isOdd)) {
+			t.Errorf("Threshold %d: expected size %d, got %d instead", threshold, set1.Filter(isOdd).Size(), filtered.Size())
+		}
+	}
+
+	small := Of(1, 2, 3)
+	empty := New[int]()
+
+	if !small.ParallelIntersection(empty, 0).IsEmpty() {
+		t.Error("Expected an empty set, got a non-empty one instead")
+	}
+
+	if !small.ParallelExcept(empty, 0).SetEquals(small) {
+		t.Errorf("Expected %v, got %v instead", small, small.ParallelExcept(empty, 0))
+	}
+
+	if !small.ParallelUnion(empty, 0).SetEquals(small) {
+		t.Errorf("Expected %v, got %v instead", small, small.ParallelUnion(empty, 0))
+	}
+}
+
+func BenchmarkSetIntersection(b *testing.B) {
+	for _, size := range []int{1 << 10, 1 << 14, 1 << 18, 1 << 20} {
+		set1 := rangeSet(0, size)
+		set2 := rangeSet(size/2, size+size/2)
+
+		b.Run(fmt.Sprintf("Sequential/%d", size), func(b *testing.B) {
+			for b.Loop() {
+				set1.Intersection(set2)
+			}
+		})
+
+		b.Run(fmt.Sprintf("Parallel/%d", size), func(b *testing.B) {
+			for b.Loop() {
+				set1.ParallelIntersection(set2, 0)
+			}
+		})
+	}
+}
+
+func BenchmarkSetExcept(b *testing.B) {
+	for _, size := range []int{1 << 10, 1 << 14, 1 << 18, 1 << 20} {
+		set1 := rangeSet(0, size)
+		set2 := rangeSet(size/2, size+size/2)
+
+		b.Run(fmt.Sprintf("Sequential/%d", size), func(b *testing.B) {
+			for b.Loop() {
+				set1.Except(set2)
+			}
+		})
+
+		b.Run(fmt.Sprintf("Parallel/%d", size), func(b *testing.B) {
+			for b.Loop() {
+				set1.ParallelExcept(set2, 0)
+			}
+		})
+	}
+}
+
+func BenchmarkSetFilter(b *testing.B) {
+	isOdd := func(val int) bool { return val%2 == 1 }
+
+	for _, size := range []int{1 << 10, 1 << 14, 1 << 18, 1 << 20} {
+		set := rangeSet(0, size)
+
+		b.Run(fmt.Sprintf("Sequential/%d", size), func(b *testing.B) {
+			for b.Loop() {
+				set.Filter(isOdd)
+			}
+		})
+
+		b.Run(fmt.Sprintf("Parallel/%d", size), func(b *testing.B) {
+			for b.Loop() {
+				set.ParallelFilter(isOdd, 0)
+			}
+		})
+	}
+}
@@ -1,13 +1,15 @@
 package set
 
 import (
-	"fmt"
 	"maps"
-	"strings"
+	"time"
+
+	"github.com/XeniaPhe/xengods/instrument"
 )
 
 type Set[T comparable] struct {
 	set map[T]struct{}
+	hook instrument.Hook
 }
 
 func New[T comparable](size ...int) Set[T] {
@@ -17,7 +19,7 @@ func New[T comparable](size ...int) Set[T] {
 		sizeHint = size[0]
 	}
 
-	return Set[T]{make(map[T]struct{}, sizeHint)}
+	return Set[T]{make(map[T]struct{}, sizeHint), nil}
 }
 
 func Of[T comparable](values ...T) Set[T] {
@@ -51,7 +53,7 @@ func FromValues[K comparable, V comparable](hashmap map[K]V) Set[V] {
 }
 
 func (s Set[T]) Clone() Set[T] {
-	return Set[T]{maps.Clone(s.set)}
+	return Set[T]{maps.Clone(s.set), nil}
 }
 
 func (s Set[T]) IsInitialized() bool {
@@ -64,7 +66,15 @@ func (s *Set[T]) InitializeIfNot() {
 	}
 }
 
+func (s *Set[T]) SetHook(hook instrument.Hook) {
+	s.hook = hook
+}
+
 func (s *Set[T]) Clear() {
+	if s.hook != nil {
+		defer s.observe(instrument.OpClear, time.Now())
+	}
+
 	s.set = make(map[T]struct{})
 }
 
@@ -81,14 +91,26 @@ func (s Set[T]) GetRawSet() map[T]struct{} {
 }
 
 func (s Set[T]) Add(value T) {
+	if s.hook != nil {
+		defer s.observe(instrument.OpAdd, time.Now())
+	}
+
 	s.set[value] = struct{}{}
 }
 
 func (s Set[T]) Remove(value T) {
+	if s.hook != nil {
+		defer s.observe(instrument.OpRemove, time.Now())
+	}
+
 	delete(s.set, value)
 }
 
 func (s Set[T]) PopOne() T {
+	if s.hook != nil {
+		defer s.observe(instrument.OpPop, time.Now())
+	}
+
 	for val := range s.set {
 		delete(s.set, val)
 		return val
@@ -140,6 +162,10 @@ func (s Set[T]) Union(other Set[T]) Set[T] {
 }
 
 func (s Set[T]) UnionWith(other Set[T]) {
+	if s.hook != nil {
+		defer s.observe(instrument.OpUnion, time.Now())
+	}
+
 	for val := range other.set {
 		s.set[val] = struct{}{}
 	}
@@ -160,6 +186,10 @@ func (s Set[T]) Intersection(other Set[T]) Set[T] {
 }
 
 func (s Set[T]) IntersectWith(other Set[T]) {
+	if s.hook != nil {
+		defer s.observe(instrument.OpIntersect, time.Now())
+	}
+
 	smaller, bigger := orderBySize(s, other)
 	var sizeHint int
 
@@ -204,6 +234,10 @@ func (s Set[T]) Except(other Set[T]) Set[T] {
 }
 
 func (s Set[T]) ExceptWith(other Set[T]) {
+	if s.hook != nil {
+		defer s.observe(instrument.OpExcept, time.Now())
+	}
+
 	smaller, _ := orderBySize(s, other)
 	sizeHint := len(smaller.set) / 2
 	marked := make([]T, 0, sizeHint)
@@ -240,6 +274,10 @@ func (s Set[T]) SymmetricExcept(other Set[T]) Set[T] {
 }
 
 func (s Set[T]) SymmetricExceptWith(other Set[T]) {
+	if s.hook != nil {
+		defer s.observe(instrument.OpSymmetricExcept, time.Now())
+	}
+
 	for val := range other.set {
 		if _, exists := s.set[val]; exists {
 			delete(s.set, val)
@@ -308,21 +346,11 @@ func (s Set[T]) ToSlice() []T {
 }
 
 func (s Set[T]) String() string {
-	var builder strings.Builder
-	builder.WriteString("Set{")
-	first := true
-
-	for val := range s.set {
-		if !first {
-			builder.WriteString(", ")
-		}
-		
-		builder.WriteString(fmt.Sprintf("%v", val))
-		first = false
-	}
+	return joinElements("Set{", s.orderedSlice(), "%v", "}")
+}
 
-	builder.WriteString("}")
-	return builder.String()
+func (s *Set[T]) observe(op instrument.Op, start time.Time) {
+	instrument.Observe(s.hook, op, len(s.set), start)
 }
 
 func orderBySize[T comparable](lhs Set[T], rhs Set[T]) (Set[T], Set[T]) {
@@ -1,6 +1,10 @@
 package set
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/XeniaPhe/xengods/instrument"
+)
 
 func TestSetConstructors(t *testing.T) {
 	var uninitialized Set[int]
@@ -531,7 +535,73 @@ func TestSetString(t *testing.T) {
 	set := Of(1, 2)
 	str := set.String()
 
-	if str != "Set{1, 2}" && str != "Set{2, 1}" {
-		t.Errorf("Expected 'Set{1, 2}' or 'Set{2, 1}', got '%s' instead", str)
+	if str != "Set{1, 2}" {
+		t.Errorf("Expected 'Set{1, 2}', got '%s' instead", str)
+	}
+}
+
+func TestSetHook(t *testing.T) {
+	s := New[int]()
+	stats := instrument.NewStats()
+	s.SetHook(stats)
+
+	for i := range 5 {
+		s.Add(i)
 	}
-}
\ No newline at end of file
+
+	s.Remove(0)
+	s.PopOne()
+	s.Clear()
+	s.Add(1)
+
+	if stats.Count(instrument.OpAdd) != 6 || stats.Count(instrument.OpRemove) != 1 {
+		t.Errorf("Expected 6 additions and 1 removal, got %d and %d instead",
+			stats.Count(instrument.OpAdd), stats.Count(instrument.OpRemove))
+	}
+
+	if stats.Count(instrument.OpPop) != 1 || stats.Count(instrument.OpClear) != 1 {
+		t.Errorf("Expected 1 pop and 1 clear, got %d and %d instead",
+			stats.Count(instrument.OpPop), stats.Count(instrument.OpClear))
+	}
+
+	if stats.HighWaterMark() != 5 || stats.Report().Size != 1 {
+		t.Errorf("Expected high-water mark 5 and size 1, got %d and %d instead", stats.HighWaterMark(), stats.Report().Size)
+	}
+}
+
+func TestSetHookBulkOperations(t *testing.T) {
+	s := New[int]()
+	stats := instrument.NewStats()
+	s.SetHook(stats)
+
+	s.Add(1)
+	s.UnionWith(Of(2, 3, 4, 5, 6))
+
+	if stats.Count(instrument.OpUnion) != 1 || stats.Report().Size != 6 || stats.HighWaterMark() != 6 {
+		t.Errorf("Expected 1 union, size 6 and high-water mark 6, got %d, %d and %d instead",
+			stats.Count(instrument.OpUnion), stats.Report().Size, stats.HighWaterMark())
+	}
+
+	s.ExceptWith(Of(5, 6))
+	s.IntersectWith(Of(1, 2, 3, 4, 9))
+	s.SymmetricExceptWith(Of(4, 7))
+	s.Apply(Changeset[int]{Of(8), Of(1)})
+
+	expected := map[instrument.Op]uint64{
+		instrument.OpUnion:           2,
+		instrument.OpIntersect:       1,
+		instrument.OpExcept:          2,
+		instrument.OpSymmetricExcept: 1,
+	}
+
+	for op, count := range expected {
+		if stats.Count(op) != count {
+			t.Errorf("Expected %d %s, got %d instead", count, op, stats.Count(op))
+		}
+	}
+
+	if !s.SetEquals(Of(2, 3, 7, 8)) || stats.Report().Size != 4 || stats.HighWaterMark() != 6 {
+		t.Errorf("Expected size 4 and high-water mark 6, got %v with %d and %d instead",
+			s, stats.Report().Size, stats.HighWaterMark())
+	}
+}
@@ -0,0 +1,218 @@
+package set
+
+import (
+	"hash/maphash"
+	"sync"
+)
+
+const DefaultShardCount = 32
+
+type shard[T comparable] struct {
+	mu  sync.RWMutex
+	set Set[T]
+}
+
+type ShardedSet[T comparable] struct {
+	shards []shard[T]
+	seed   maphash.Seed
+}
+
+func NewSharded[T comparable](shardCount int, size ...int) *ShardedSet[T] {
+	if shardCount <= 0 {
+		shardCount = DefaultShardCount
+	}
+
+	sizeHint := 0
+
+	if len(size) > 0 {
+		sizeHint = size[0] / shardCount
+	}
+
+	shards := make([]shard[T], shardCount)
+
+	for i := range shards {
+		shards[i].set = New[T](sizeHint)
+	}
+
+	return &ShardedSet[T]{shards, maphash.MakeSeed()}
+}
+
+func ShardedOf[T comparable](shardCount int, values ...T) *ShardedSet[T] {
+	sharded := NewSharded[T](shardCount, len(values))
+
+	for _, val := range values {
+		sharded.shardOf(val).set.Add(val)
+	}
+
+	return sharded
+}
+
+func (s *ShardedSet[T]) ShardCount() int {
+	return len(s.shards)
+}
+
+func (s *ShardedSet[T]) Add(value T) {
+	shard := s.shardOf(value)
+	shard.mu.Lock()
+	shard.set.Add(value)
+	shard.mu.Unlock()
+}
+
+func (s *ShardedSet[T]) Remove(value T) {
+	shard := s.shardOf(value)
+	shard.mu.Lock()
+	shard.set.Remove(value)
+	shard.mu.Unlock()
+}
+
+func (s *ShardedSet[T]) Contains(value T) bool {
+	shard := s.shardOf(value)
+	shard.mu.RLock()
+	defer shard.mu.RUnlock()
+	return shard.set.Contains(value)
+}
+
+func (s *ShardedSet[T]) Size() int {
+	s.rlockAll()
+	defer s.runlockAll()
+	return s.sizeLocked()
+}
+
+func (s *ShardedSet[T]) IsEmpty() bool {
+	return s.Size() == 0
+}
+
+func (s *ShardedSet[T]) Clear() {
+	s.lockAll()
+	defer s.unlockAll()
+
+	for i := range s.shards {
+		s.shards[i].set.Clear()
+	}
+}
+
+func (s *ShardedSet[T]) ToSlice() []T {
+	s.rlockAll()
+	defer s.runlockAll()
+	return s.toSliceLocked()
+}
+
+func (s *ShardedSet[T]) Snapshot() Set[T] {
+	s.rlockAll()
+	defer s.runlockAll()
+	return Of(s.toSliceLocked()...)
+}
+
+func (s *ShardedSet[T]) Union(other Set[T]) Set[T] {
+	union := s.Snapshot()
+	union.UnionWith(other)
+	return union
+}
+
+func (s *ShardedSet[T]) Intersection(other Set[T]) Set[T] {
+	s.rlockAll()
+	defer s.runlockAll()
+	intersection := New[T]()
+
+	if other.Size() < s.sizeLocked() {
+		for val := range other.set {
+			if s.shardOf(val).set.Contains(val) {
+				intersection.set[val] = struct{}{}
+			}
+		}
+
+		return intersection
+	}
+
+	for i := range s.shards {
+		for val := range s.shards[i].set.set {
+			if other.Contains(val) {
+				intersection.set[val] = struct{}{}
+			}
+		}
+	}
+
+	return intersection
+}
+
+func (s *ShardedSet[T]) UnionWith(other Set[T]) {
+	s.lockAll()
+	defer s.unlockAll()
+
+	for val := range other.set {
+		s.shardOf(val).set.Add(val)
+	}
+}
+
+func (s *ShardedSet[T]) IntersectWith(other Set[T]) {
+	s.lockAll()
+	defer s.unlockAll()
+
+	for i := range s.shards {
+		s.shards[i].set.IntersectWith(other)
+	}
+}
+
+func (s *ShardedSet[T]) ExceptWith(other Set[T]) {
+	s.lockAll()
+	defer s.unlockAll()
+
+	for val := range other.set {
+		s.shardOf(val).set.Remove(val)
+	}
+}
+
+func (s *ShardedSet[T]) String() string {
+	return joinElements("ShardedSet{", s.Snapshot().orderedSlice(), "%v", "}")
+}
+
+func (s *ShardedSet[T]) shardOf(value T) *shard[T] {
+	hash := maphash.Comparable(s.seed, value)
+	return &s.shards[hash%uint64(len(s.shards))]
+}
+
+func (s *ShardedSet[T]) sizeLocked() int {
+	size := 0
+
+	for i := range s.shards {
+		size += s.shards[i].set.Size()
+	}
+
+	return size
+}
+
+func (s *ShardedSet[T]) toSliceLocked() []T {
+	slice := make([]T, 0, s.sizeLocked())
+
+	for i := range s.shards {
+		for val := range s.shards[i].set.set {
+			slice = append(slice, val)
+		}
+	}
+
+	return slice
+}
+
+func (s *ShardedSet[T]) lockAll() {
+	for i := range s.shards {
+		s.shards[i].mu.Lock()
+	}
+}
+
+func (s *ShardedSet[T]) unlockAll() {
+	for i := len(s.shards) - 1; i >= 0; i-- {
+		s.shards[i].mu.Unlock()
+	}
+}
+
+func (s *ShardedSet[T]) rlockAll() {
+	for i := range s.shards {
+		s.shards[i].mu.RLock()
+	}
+}
+
+func (s *ShardedSet[T]) runlockAll() {
+	for i := len(s.shards) - 1; i >= 0; i-- {
+		s.shards[i].mu.RUnlock()
+	}
+}
@@ -0,0 +1,155 @@
+package set
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestShardedSetConstructors(t *testing.T) {
+	s := NewSharded[int](0)
+
+	if s.ShardCount() != DefaultShardCount {
+		t.Errorf("Expected %d shards, got %d instead", DefaultShardCount, s.ShardCount())
+	}
+
+	if !s.IsEmpty() {
+		t.Errorf("Expected size 0, got %d instead", s.Size())
+	}
+
+	s = ShardedOf(4, 1, 2, 3, 3)
+
+	if s.ShardCount() != 4 {
+		t.Errorf("Expected 4 shards, got %d instead", s.ShardCount())
+	}
+
+	if s.Size() != 3 {
+		t.Errorf("Expected size 3, got %d instead", s.Size())
+	}
+
+	if !s.Snapshot().SetEquals(Of(1, 2, 3)) {
+		t.Errorf("Expected %v, got %v instead", Of(1, 2, 3), s)
+	}
+}
+
+func TestShardedSetAddRemoveContains(t *testing.T) {
+	s := NewSharded[string](8)
+	s.Add("a")
+	s.Add("b")
+	s.Add("a")
+
+	if !s.Contains("a") || !s.Contains("b") {
+		t.Error("Set does not contain all the elements it should have contained")
+	}
+
+	if s.Contains("c") {
+		t.Error("Set contains an unexpected item: c")
+	}
+
+	s.Remove("a")
+
+	if s.Contains("a") {
+		t.Error("Set contains an unexpected item: a")
+	}
+
+	if s.Size() != 1 {
+		t.Errorf("Expected size 1, got %d instead", s.Size())
+	}
+
+	if len(s.ToSlice()) != 1 {
+		t.Errorf("Expected length 1, got %d instead", len(s.ToSlice()))
+	}
+
+	s.Clear()
+
+	if !s.IsEmpty() {
+		t.Errorf("Expected size 0, got %d instead", s.Size())
+	}
+}
+
+func TestShardedSetOperations(t *testing.T) {
+	s := ShardedOf(4, 1, 2, 3, 4)
+	other := Of(3, 4, 5, 6)
+
+	if !s.Union(other).SetEquals(Of(1, 2, 3, 4, 5, 6)) {
+		t.Errorf("Expected %v, got %v instead", Of(1, 2, 3, 4, 5, 6), s.Union(other))
+	}
+
+	if !s.Intersection(other).SetEquals(Of(3, 4)) {
+		t.Errorf("Expected %v, got %v instead", Of(3, 4), s.Intersection(other))
+	}
+
+	if !s.Intersection(Of(4, 9)).SetEquals(Of(4)) {
+		t.Errorf("Expected %v, got %v instead", Of(4), s.Intersection(Of(4, 9)))
+	}
+
+	if !s.Intersection(Of(0, 1, 2, 3, 7, 8)).SetEquals(Of(1, 2, 3)) {
+		t.Errorf("Expected %v, got %v instead", Of(1, 2, 3), s.Intersection(Of(0, 1, 2, 3, 7, 8)))
+	}
+
+	if s.Size() != 4 {
+		t.Errorf("Expected size 4, got %d instead", s.Size())
+	}
+
+	s.UnionWith(other)
+
+	if !s.Snapshot().SetEquals(Of(1, 2, 3, 4, 5, 6)) {
+		t.Errorf("Expected %v, got %v instead", Of(1, 2, 3, 4, 5, 6), s)
+	}
+
+	s.ExceptWith(Of(1, 6))
+
+	if !s.Snapshot().SetEquals(Of(2, 3, 4, 5)) {
+		t.Errorf("Expected %v, got %v instead", Of(2, 3, 4, 5), s)
+	}
+
+	s.IntersectWith(Of(3, 5, 7))
+
+	if !s.Snapshot().SetEquals(Of(3, 5)) {
+		t.Errorf("Expected %v, got %v instead", Of(3, 5), s)
+	}
+}
+
+func TestShardedSetConcurrentAccess(t *testing.T) {
+	s := NewSharded[int](16)
+	var wg sync.WaitGroup
+
+	for w := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range 1000 {
+				val := w*1000 + i
+				s.Add(val)
+
+				if !s.Contains(val) {
+					t.Errorf("Set does not contain expected item: %d", val)
+				}
+
+				if i%2 == 1 {
+					s.Remove(val)
+				}
+			}
+		}()
+	}
+
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for range 20 {
+				s.Size()
+				s.ToSlice()
+				s.Union(Of(-1))
+				s.Intersection(Of(1, 2, 3))
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if s.Size() != 4000 {
+		t.Errorf("Expected size 4000, got %d instead", s.Size())
+	}
+}
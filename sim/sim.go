@@ -0,0 +1,253 @@
+package sim
+
+import (
+	"errors"
+	"iter"
+	"slices"
+
+	"github.com/XeniaPhe/xengods/pqueue"
+)
+
+var pastEventError error
+var stoppedProcessError error
+
+func init() {
+	pastEventError = errors.New("cannot schedule an event in the past")
+	stoppedProcessError = errors.New("process is not running")
+}
+
+type Time int64
+
+type Action func(s *Scheduler)
+
+type Event struct {
+	at        Time
+	seq       uint64
+	action    Action
+	cancelled bool
+	done      bool
+}
+
+func (e *Event) At() Time {
+	return e.at
+}
+
+func (e *Event) IsPending() bool {
+	return !e.cancelled && !e.done
+}
+
+type events []*Event
+
+func (e events) Len() int {
+	return len(e)
+}
+
+func (e events) Less(i int, j int) bool {
+	if e[i].at != e[j].at {
+		return e[i].at < e[j].at
+	}
+
+	return e[i].seq < e[j].seq
+}
+
+func (e events) Top() *Event {
+	return e[0]
+}
+
+func (e events) Swap(i int, j int) {
+	e[i], e[j] = e[j], e[i]
+}
+
+func (e *events) Push(x any) {
+	*e = append(*e, x.(*Event))
+}
+
+func (e *events) Pop() any {
+	old := *e
+	last := len(old) - 1
+	event := old[last]
+	old[last] = nil
+	*e = old[:last]
+	return event
+}
+
+type Scheduler struct {
+	queue     pqueue.HeapQueue[*Event]
+	now       Time
+	seq       uint64
+	pending   int
+	processes []*Process
+}
+
+func New() *Scheduler {
+	return &Scheduler{queue: pqueue.WrapHeap[*Event](&events{})}
+}
+
+func (s *Scheduler) Now() Time {
+	return s.now
+}
+
+func (s *Scheduler) Schedule(at Time, action Action) (*Event, error) {
+	if at < s.now {
+		return nil, pastEventError
+	}
+
+	event := &Event{at: at, seq: s.seq, action: action}
+	s.seq++
+	s.pending++
+	s.queue.Enqueue(event)
+	return event, nil
+}
+
+func (s *Scheduler) ScheduleAfter(delay Time, action Action) (*Event, error) {
+	return s.Schedule(s.now+delay, action)
+}
+
+func (s *Scheduler) Cancel(event *Event) bool {
+	if event == nil || !event.IsPending() {
+		return false
+	}
+
+	event.cancelled = true
+	s.pending--
+	return true
+}
+
+func (s *Scheduler) Peek() (Time, bool) {
+	s.dropCancelled()
+	next, err := s.queue.Peek()
+
+	if err != nil {
+		return 0, false
+	}
+
+	return next.at, true
+}
+
+func (s *Scheduler) Step() bool {
+	s.dropCancelled()
+
+	if s.queue.IsEmpty() {
+		return false
+	}
+
+	event, _ := s.queue.Dequeue()
+	event.done = true
+	s.pending--
+	s.now = event.at
+	event.action(s)
+	return true
+}
+
+func (s *Scheduler) RunUntil(until Time) int {
+	count := 0
+
+	for {
+		at, found := s.Peek()
+
+		if !found || at > until {
+			break
+		}
+
+		s.Step()
+		count++
+	}
+
+	s.now = max(s.now, until)
+	return count
+}
+
+func (s *Scheduler) Run() int {
+	count := 0
+
+	for s.Step() {
+		count++
+	}
+
+	return count
+}
+
+func (s *Scheduler) Pending() int {
+	return s.pending
+}
+
+func (s *Scheduler) IsIdle() bool {
+	return s.pending == 0
+}
+
+func (s *Scheduler) Clear() {
+	for _, process := range slices.Clone(s.processes) {
+		process.Stop()
+	}
+
+	for !s.queue.IsEmpty() {
+		event, _ := s.queue.Dequeue()
+		event.cancelled = true
+	}
+
+	s.pending = 0
+}
+
+func (s *Scheduler) dropCancelled() {
+	for next, err := s.queue.Peek(); err == nil && next.cancelled; next, err = s.queue.Peek() {
+		s.queue.Dequeue()
+	}
+}
+
+// A process yields the delay before it resumes. It must not stop itself or
+// clear its scheduler; it should return from the iterator instead.
+type Process struct {
+	scheduler *Scheduler
+	next      func() (Time, bool)
+	stop      func()
+	wakeup    *Event
+	done      bool
+}
+
+func (s *Scheduler) Spawn(body iter.Seq[Time]) *Process {
+	next, stop := iter.Pull(body)
+	process := &Process{scheduler: s, next: next, stop: stop}
+	s.processes = append(s.processes, process)
+	process.wakeup, _ = s.ScheduleAfter(0, process.resume)
+	return process
+}
+
+func (p *Process) IsDone() bool {
+	return p.done
+}
+
+func (p *Process) Interrupt() error {
+	if p.done {
+		return stoppedProcessError
+	}
+
+	p.scheduler.Cancel(p.wakeup)
+	p.wakeup, _ = p.scheduler.ScheduleAfter(0, p.resume)
+	return nil
+}
+
+func (p *Process) Stop() {
+	if p.done {
+		return
+	}
+
+	p.scheduler.Cancel(p.wakeup)
+	p.finish()
+}
+
+func (p *Process) resume(s *Scheduler) {
+	delay, ok := p.next()
+
+	if !ok {
+		p.finish()
+		return
+	}
+
+	p.wakeup, _ = s.ScheduleAfter(max(delay, 0), p.resume)
+}
+
+func (p *Process) finish() {
+	p.done = true
+	p.stop()
+	p.scheduler.processes = slices.DeleteFunc(p.scheduler.processes, func(other *Process) bool { return other == p })
+}
@@ -0,0 +1,120 @@
+package sim
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestScheduleOrderAndTies(t *testing.T) {
+	s := New()
+	var log []string
+
+	record := func(name string) Action {
+		return func(s *Scheduler) { log = append(log, fmt.Sprintf("%s@%d", name, s.Now())) }
+	}
+
+	s.Schedule(5, record("c"))
+	s.Schedule(1, record("a"))
+	s.Schedule(5, record("d"))
+	s.Schedule(3, func(s *Scheduler) {
+		log = append(log, fmt.Sprintf("b@%d", s.Now()))
+		s.ScheduleAfter(2, record("e"))
+		s.ScheduleAfter(0, record("f"))
+	})
+
+	cancelled, _ := s.Schedule(4, record("x"))
+
+	if !s.Cancel(cancelled) || s.Cancel(cancelled) {
+		t.Error("Expected the event to be cancelled exactly once")
+	}
+
+	if s.Pending() != 4 {
+		t.Errorf("Expected 4 pending events, got %d instead", s.Pending())
+	}
+
+	if count := s.Run(); count != 6 {
+		t.Errorf("Expected 6 events to run, got %d instead", count)
+	}
+
+	expected := []string{"a@1", "b@3", "f@3", "c@5", "d@5", "e@5"}
+
+	if !slices.Equal(log, expected) {
+		t.Errorf("Expected %v, got %v instead", expected, log)
+	}
+
+	if _, err := s.Schedule(1, record("late")); err == nil {
+		t.Error("Expected an error when scheduling in the past")
+	}
+}
+
+func TestRunUntilAndStep(t *testing.T) {
+	s := New()
+	fired := 0
+
+	for i := range 10 {
+		s.Schedule(Time(i*10), func(*Scheduler) { fired++ })
+	}
+
+	if count := s.RunUntil(35); count != 4 || fired != 4 || s.Now() != 35 {
+		t.Errorf("Expected 4 events and time 35, got %d, %d and %d instead", count, fired, s.Now())
+	}
+
+	if at, _ := s.Peek(); at != 40 {
+		t.Errorf("Expected the next event at 40, got %d instead", at)
+	}
+
+	if !s.Step() || s.Now() != 40 || fired != 5 {
+		t.Errorf("Expected to step to 40, got %d with %d fired instead", s.Now(), fired)
+	}
+
+	s.Clear()
+
+	if s.Step() || !s.IsIdle() || s.Now() != 40 {
+		t.Error("Expected an idle scheduler after clearing")
+	}
+}
+
+func TestProcesses(t *testing.T) {
+	run := func() []string {
+		s := New()
+		var log []string
+
+		worker := func(name string, period Time, times int) func(yield func(Time) bool) {
+			return func(yield func(Time) bool) {
+				for i := range times {
+					log = append(log, fmt.Sprintf("%s%d@%d", name, i, s.Now()))
+
+					if !yield(period) {
+						return
+					}
+				}
+			}
+		}
+
+		s.Spawn(worker("a", 3, 3))
+		b := s.Spawn(worker("b", 2, 10))
+		s.Schedule(5, func(*Scheduler) { b.Interrupt() })
+		s.Schedule(8, func(*Scheduler) { b.Stop() })
+		s.Run()
+
+		if !b.IsDone() || b.Interrupt() == nil {
+			t.Error("Expected b to be stopped")
+		}
+
+		return log
+	}
+
+	expected := []string{"a0@0", "b0@0", "b1@2", "a1@3", "b2@4", "b3@5", "a2@6", "b4@7"}
+	first := run()
+
+	if !slices.Equal(first, expected) {
+		t.Errorf("Expected %v, got %v instead", expected, first)
+	}
+
+	for range 20 {
+		if again := run(); !slices.Equal(first, again) {
+			t.Fatalf("Expected a deterministic run, got %v after %v", again, first)
+		}
+	}
+}
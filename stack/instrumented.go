@@ -0,0 +1,56 @@
+package stack
+
+import (
+	"time"
+
+	"github.com/XeniaPhe/xengods/instrument"
+)
+
+type InstrumentedStack[T any] struct {
+	Stack[T]
+	hook instrument.Hook
+}
+
+func Instrument[T any](stack Stack[T], hook instrument.Hook) *InstrumentedStack[T] {
+	return &InstrumentedStack[T]{stack, hook}
+}
+
+func (s *InstrumentedStack[T]) SetHook(hook instrument.Hook) {
+	s.hook = hook
+}
+
+func (s *InstrumentedStack[T]) Push(value T) {
+	if s.hook != nil {
+		defer s.observe(instrument.OpPush, time.Now())
+	}
+
+	s.Stack.Push(value)
+}
+
+func (s *InstrumentedStack[T]) Pop() (T, error) {
+	if s.hook != nil {
+		defer s.observe(instrument.OpPop, time.Now())
+	}
+
+	return s.Stack.Pop()
+}
+
+func (s *InstrumentedStack[T]) Peek() (T, error) {
+	if s.hook != nil {
+		defer s.observe(instrument.OpPeek, time.Now())
+	}
+
+	return s.Stack.Peek()
+}
+
+func (s *InstrumentedStack[T]) Clear() {
+	if s.hook != nil {
+		defer s.observe(instrument.OpClear, time.Now())
+	}
+
+	s.Stack.Clear()
+}
+
+func (s *InstrumentedStack[T]) observe(op instrument.Op, start time.Time) {
+	instrument.Observe(s.hook, op, len(s.Stack), start)
+}
@@ -0,0 +1,56 @@
+package stack
+
+import (
+	"testing"
+
+	"github.com/XeniaPhe/xengods/instrument"
+)
+
+func TestInstrumentedStack(t *testing.T) {
+	stats := instrument.NewStats()
+	s := Instrument(Of(1, 2), stats)
+
+	s.Push(3)
+	s.Push(4)
+	s.Peek()
+	value, err := s.Pop()
+
+	if err != nil {
+		t.Errorf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	if value != 4 {
+		t.Errorf("Expected 4, got %d instead", value)
+	}
+
+	if s.Size() != 3 {
+		t.Errorf("Expected size 3, got %d instead", s.Size())
+	}
+
+	s.Clear()
+
+	if stats.Count(instrument.OpPush) != 2 || stats.Count(instrument.OpPop) != 1 {
+		t.Errorf("Expected 2 pushes and 1 pop, got %d and %d instead",
+			stats.Count(instrument.OpPush), stats.Count(instrument.OpPop))
+	}
+
+	if stats.Count(instrument.OpPeek) != 1 || stats.Count(instrument.OpClear) != 1 {
+		t.Errorf("Expected 1 peek and 1 clear, got %d and %d instead",
+			stats.Count(instrument.OpPeek), stats.Count(instrument.OpClear))
+	}
+
+	if stats.HighWaterMark() != 4 {
+		t.Errorf("Expected high-water mark 4, got %d instead", stats.HighWaterMark())
+	}
+
+	s.SetHook(nil)
+	s.Push(1)
+
+	if stats.Count(instrument.OpPush) != 2 {
+		t.Errorf("Expected 2 pushes, got %d instead", stats.Count(instrument.OpPush))
+	}
+
+	if s.String() != "Stack[1]" {
+		t.Errorf("Expected '%s', got '%s' instead", "Stack[1]", s.String())
+	}
+}
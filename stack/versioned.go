@@ -0,0 +1,80 @@
+package stack
+
+import "slices"
+
+type Snapshot[T any] struct {
+	stack Stack[T]
+	version uint64
+}
+
+func (s Snapshot[T]) Version() uint64 {
+	return s.version
+}
+
+func (s Snapshot[T]) Size() int {
+	return len(s.stack)
+}
+
+func (s Snapshot[T]) IsEmpty() bool {
+	return len(s.stack) == 0
+}
+
+func (s Snapshot[T]) Stack() Stack[T] {
+	return s.stack.Clone()
+}
+
+type VersionedStack[T any] struct {
+	Stack[T]
+	version uint64
+	shared bool
+}
+
+func Versioned[T any](stack Stack[T]) *VersionedStack[T] {
+	return &VersionedStack[T]{stack, 0, false}
+}
+
+func (s *VersionedStack[T]) Version() uint64 {
+	return s.version
+}
+
+func (s *VersionedStack[T]) Snapshot() Snapshot[T] {
+	s.shared = true
+	return Snapshot[T]{slices.Clip(s.Stack), s.version}
+}
+
+func (s *VersionedStack[T]) Restore(snapshot Snapshot[T]) {
+	s.Stack = snapshot.stack
+	s.shared = true
+	s.version++
+}
+
+func (s *VersionedStack[T]) Push(value T) {
+	if s.shared {
+		s.Stack = append(make(Stack[T], 0, max(cap(s.Stack), len(s.Stack)+1)), s.Stack...)
+		s.shared = false
+	}
+
+	s.version++
+	s.Stack.Push(value)
+}
+
+func (s *VersionedStack[T]) Pop() (T, error) {
+	value, err := s.Stack.Pop()
+
+	if err == nil {
+		s.version++
+	}
+
+	return value, err
+}
+
+func (s *VersionedStack[T]) Clear() {
+	if s.shared {
+		s.Stack = make(Stack[T], 0, cap(s.Stack))
+		s.shared = false
+	} else {
+		s.Stack.Clear()
+	}
+
+	s.version++
+}
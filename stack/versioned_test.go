@@ -0,0 +1,61 @@
+package stack
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestVersionedStackSnapshot(t *testing.T) {
+	s := Versioned(New[int](8))
+	s.Push(1)
+	s.Push(2)
+	s.Push(3)
+
+	snapshot := s.Snapshot()
+
+	if snapshot.Version() != s.Version() || snapshot.Size() != 3 {
+		t.Errorf("Expected snapshot version %d and size 3, got %d and %d instead",
+			s.Version(), snapshot.Version(), snapshot.Size())
+	}
+
+	s.Pop()
+	s.Pop()
+	s.Push(20)
+	s.Push(30)
+
+	if snapshot.Version() == s.Version() {
+		t.Error("Expected the version to change after mutations")
+	}
+
+	if got := snapshot.Stack(); !slices.Equal(got, Stack[int]{1, 2, 3}) {
+		t.Errorf("Expected the snapshot to be unchanged, got %v instead", got)
+	}
+
+	if !slices.Equal(s.Stack, Stack[int]{1, 20, 30}) {
+		t.Errorf("Expected [1 20 30], got %v instead", s.Stack)
+	}
+
+	s.Restore(snapshot)
+	s.Clear()
+	s.Push(7)
+	s.Restore(snapshot)
+	s.Push(4)
+
+	if got := snapshot.Stack(); !slices.Equal(got, Stack[int]{1, 2, 3}) {
+		t.Errorf("Expected the snapshot to survive Clear and Push, got %v instead", got)
+	}
+
+	if !slices.Equal(s.Stack, Stack[int]{1, 2, 3, 4}) {
+		t.Errorf("Expected [1 2 3 4], got %v instead", s.Stack)
+	}
+
+	version := s.Version()
+
+	if _, err := Versioned(New[int]()).Pop(); err == nil {
+		t.Error("Expected an error when popping an empty stack")
+	}
+
+	if s.Version() != version {
+		t.Error("Expected the version to stay the same without mutations")
+	}
+}
@@ -0,0 +1,234 @@
+package timerwheel
+
+import (
+	"time"
+)
+
+var DefaultWheelSizes = []int{256, 64, 64, 64}
+
+type Timer[T any] struct {
+	value T
+	expires int64
+	bucket *bucket[T]
+	prev *Timer[T]
+	next *Timer[T]
+}
+
+func (t *Timer[T]) Value() T {
+	return t.value
+}
+
+func (t *Timer[T]) IsPending() bool {
+	return t.bucket != nil
+}
+
+type bucket[T any] struct {
+	head *Timer[T]
+	tail *Timer[T]
+}
+
+func (b *bucket[T]) push(timer *Timer[T]) {
+	timer.bucket = b
+	timer.prev = b.tail
+	timer.next = nil
+
+	if b.tail != nil {
+		b.tail.next = timer
+	} else {
+		b.head = timer
+	}
+
+	b.tail = timer
+}
+
+func (b *bucket[T]) remove(timer *Timer[T]) {
+	if timer.prev != nil {
+		timer.prev.next = timer.next
+	} else {
+		b.head = timer.next
+	}
+
+	if timer.next != nil {
+		timer.next.prev = timer.prev
+	} else {
+		b.tail = timer.prev
+	}
+
+	timer.bucket, timer.prev, timer.next = nil, nil, nil
+}
+
+func (b *bucket[T]) take() *Timer[T] {
+	head := b.head
+	b.head, b.tail = nil, nil
+	return head
+}
+
+type wheel[T any] struct {
+	slots []bucket[T]
+	granularity int64
+}
+
+// Timers never fire before their deadline but may fire up to one tick after it.
+type TimerWheel[T any] struct {
+	wheels []wheel[T]
+	start time.Time
+	tick time.Duration
+	current int64
+	size int
+}
+
+func New[T any](start time.Time, tick time.Duration, sizes ...int) *TimerWheel[T] {
+	if len(sizes) == 0 {
+		sizes = DefaultWheelSizes
+	}
+
+	wheels := make([]wheel[T], len(sizes))
+	granularity := int64(1)
+
+	for i, size := range sizes {
+		size = max(size, 2)
+		wheels[i] = wheel[T]{make([]bucket[T], size), granularity}
+		granularity *= int64(size)
+	}
+
+	return &TimerWheel[T]{wheels: wheels, start: start, tick: max(tick, 1)}
+}
+
+func (w *TimerWheel[T]) Tick() time.Duration {
+	return w.tick
+}
+
+func (w *TimerWheel[T]) Now() time.Time {
+	return w.start.Add(time.Duration(w.current) * w.tick)
+}
+
+func (w *TimerWheel[T]) Span() time.Duration {
+	last := w.wheels[len(w.wheels)-1]
+	return time.Duration(last.granularity*int64(len(last.slots))) * w.tick
+}
+
+func (w *TimerWheel[T]) Schedule(value T, at time.Time) *Timer[T] {
+	timer := &Timer[T]{value: value, expires: w.ticksUntil(at)}
+	w.insert(timer)
+	w.size++
+	return timer
+}
+
+func (w *TimerWheel[T]) ScheduleAfter(value T, delay time.Duration) *Timer[T] {
+	return w.Schedule(value, w.Now().Add(delay))
+}
+
+func (w *TimerWheel[T]) Cancel(timer *Timer[T]) bool {
+	if timer == nil || timer.bucket == nil {
+		return false
+	}
+
+	timer.bucket.remove(timer)
+	w.size--
+	return true
+}
+
+func (w *TimerWheel[T]) Advance(now time.Time) []T {
+	target := int64(now.Sub(w.start) / w.tick)
+	var expired []T
+
+	for w.current < target {
+		if w.size == 0 {
+			w.current = target
+			break
+		}
+
+		w.current++
+		w.cascade()
+		expired = w.expire(expired)
+	}
+
+	return expired
+}
+
+func (w *TimerWheel[T]) Clear() {
+	for i := range w.wheels {
+		for j := range w.wheels[i].slots {
+			for timer := w.wheels[i].slots[j].head; timer != nil; {
+				next := timer.next
+				timer.bucket, timer.prev, timer.next = nil, nil, nil
+				timer = next
+			}
+
+			w.wheels[i].slots[j].take()
+		}
+	}
+
+	w.size = 0
+}
+
+func (w *TimerWheel[T]) Size() int {
+	return w.size
+}
+
+func (w *TimerWheel[T]) IsEmpty() bool {
+	return w.size == 0
+}
+
+func (w *TimerWheel[T]) ticksUntil(at time.Time) int64 {
+	elapsed := at.Sub(w.start)
+	ticks := int64(elapsed / w.tick)
+
+	if elapsed%w.tick > 0 {
+		ticks++
+	}
+
+	return max(ticks, w.current+1)
+}
+
+func (w *TimerWheel[T]) insert(timer *Timer[T]) {
+	for i := range w.wheels {
+		wheel := &w.wheels[i]
+		size := int64(len(wheel.slots))
+		slot := timer.expires / wheel.granularity
+
+		if slot-w.current/wheel.granularity < size {
+			wheel.slots[slot%size].push(timer)
+			return
+		}
+	}
+
+	// Beyond the span of every wheel: park it in the coarsest wheel's last
+	// slot before a full revolution and place it properly once it cascades.
+	last := &w.wheels[len(w.wheels)-1]
+	size := int64(len(last.slots))
+	last.slots[(w.current/last.granularity+size-1)%size].push(timer)
+}
+
+func (w *TimerWheel[T]) cascade() {
+	for i := len(w.wheels) - 1; i > 0; i-- {
+		wheel := &w.wheels[i]
+
+		if w.current%wheel.granularity != 0 {
+			continue
+		}
+
+		bucket := &wheel.slots[(w.current/wheel.granularity)%int64(len(wheel.slots))]
+
+		for timer := bucket.take(); timer != nil; {
+			next := timer.next
+			w.insert(timer)
+			timer = next
+		}
+	}
+}
+
+func (w *TimerWheel[T]) expire(expired []T) []T {
+	wheel := &w.wheels[0]
+	bucket := &wheel.slots[w.current%int64(len(wheel.slots))]
+
+	for timer := bucket.take(); timer != nil; {
+		next := timer.next
+		timer.bucket, timer.prev, timer.next = nil, nil, nil
+		expired = append(expired, timer.value)
+		w.size--
+		timer = next
+	}
+
+	return expired
+}
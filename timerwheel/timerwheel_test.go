@@ -0,0 +1,147 @@
+package timerwheel
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/XeniaPhe/xengods/pqueue"
+)
+
+var epoch = time.Unix(0, 0)
+
+func TestScheduleAdvanceCancel(t *testing.T) {
+	w := New[string](epoch, time.Millisecond, 4, 4)
+
+	w.Schedule("b", epoch.Add(3*time.Millisecond))
+	w.Schedule("a", epoch.Add(1500*time.Microsecond))
+	c := w.ScheduleAfter("c", 3*time.Millisecond)
+	w.Schedule("d", epoch.Add(9*time.Millisecond))
+	w.Schedule("e", epoch.Add(40*time.Millisecond))
+	w.Schedule("past", epoch.Add(-time.Second))
+
+	if w.Size() != 6 || w.Span() != 16*time.Millisecond {
+		t.Errorf("Expected size 6 and span 16ms, got %d and %v instead", w.Size(), w.Span())
+	}
+
+	if expired := w.Advance(epoch.Add(2 * time.Millisecond)); !slices.Equal(expired, []string{"past", "a"}) {
+		t.Errorf("Expected [past a], got %v instead", expired)
+	}
+
+	if !w.Cancel(c) || w.Cancel(c) || c.IsPending() {
+		t.Error("Expected the timer to be cancelled exactly once")
+	}
+
+	if expired := w.Advance(epoch.Add(10 * time.Millisecond)); !slices.Equal(expired, []string{"b", "d"}) {
+		t.Errorf("Expected [b d], got %v instead", expired)
+	}
+
+	if expired := w.Advance(epoch.Add(39 * time.Millisecond)); len(expired) != 0 {
+		t.Errorf("Expected nothing to expire, got %v instead", expired)
+	}
+
+	if expired := w.Advance(epoch.Add(40 * time.Millisecond)); !slices.Equal(expired, []string{"e"}) || !w.IsEmpty() {
+		t.Errorf("Expected [e] and an empty wheel, got %v and %d instead", expired, w.Size())
+	}
+
+	w.Advance(epoch.Add(time.Hour))
+	timer := w.ScheduleAfter("f", time.Millisecond)
+	w.Clear()
+
+	if timer.IsPending() || len(w.Advance(epoch.Add(2*time.Hour))) != 0 {
+		t.Error("Expected clear to drop pending timers")
+	}
+}
+
+func TestAgainstReference(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	w := New[int](epoch, time.Millisecond, 8, 4, 4)
+	deadlines := make(map[int]int64)
+	timers := make(map[int]*Timer[int])
+	now := int64(0)
+
+	for id := range 5000 {
+		deadline := now + rng.Int63n(600)
+		timers[id] = w.Schedule(id, epoch.Add(time.Duration(deadline)*time.Millisecond))
+		deadlines[id] = max(deadline, now+1)
+
+		if rng.Intn(4) == 0 {
+			victim := rng.Intn(id + 1)
+
+			if w.Cancel(timers[victim]) {
+				delete(deadlines, victim)
+			}
+		}
+
+		if rng.Intn(10) == 0 {
+			now += rng.Int63n(40)
+
+			for _, expiredID := range w.Advance(epoch.Add(time.Duration(now) * time.Millisecond)) {
+				deadline, found := deadlines[expiredID]
+
+				if !found || deadline > now {
+					t.Fatalf("Timer %d expired at %d with deadline %d (pending %v)", expiredID, now, deadline, found)
+				}
+
+				delete(deadlines, expiredID)
+			}
+
+			for pendingID, deadline := range deadlines {
+				if deadline <= now {
+					t.Fatalf("Timer %d with deadline %d did not expire at %d", pendingID, deadline, now)
+				}
+			}
+		}
+	}
+
+	if w.Size() != len(deadlines) {
+		t.Errorf("Expected size %d, got %d instead", len(deadlines), w.Size())
+	}
+}
+
+const benchmarkTimers = 100_000
+
+func BenchmarkTimers(b *testing.B) {
+	rng := rand.New(rand.NewSource(42))
+	deadlines := make([]int32, benchmarkTimers)
+
+	for i := range deadlines {
+		deadlines[i] = 1 + rng.Int31n(30_000)
+	}
+
+	b.Run("TimerWheel", func(b *testing.B) {
+		for range b.N {
+			w := New[int](epoch, time.Millisecond)
+			timers := make([]*Timer[int], len(deadlines))
+
+			for i, deadline := range deadlines {
+				timers[i] = w.Schedule(i, epoch.Add(time.Duration(deadline)*time.Millisecond))
+			}
+
+			for i := 0; i < len(timers); i += 2 {
+				w.Cancel(timers[i])
+			}
+
+			w.Advance(epoch.Add(30_001 * time.Millisecond))
+		}
+	})
+
+	b.Run("PQueue", func(b *testing.B) {
+		for range b.N {
+			pq := pqueue.NewUniquePQueue[int](true, pqueue.ReplaceExisting)
+
+			for i, deadline := range deadlines {
+				pq.Enqueue(i, deadline)
+			}
+
+			for i := 0; i < len(deadlines); i += 2 {
+				pq.Remove(i)
+			}
+
+			for !pq.IsEmpty() {
+				pq.Dequeue()
+			}
+		}
+	})
+}
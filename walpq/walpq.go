@@ -0,0 +1,505 @@
+// Package walpq implements a priority queue that persists every Enqueue and
+// Dequeue to an append-only write-ahead log and rebuilds its heap on Open.
+//
+// Log format (all integers big endian):
+//
+//	header: magic "XGPQWAL" (7 bytes) | version (1 byte, currently 1) | order (1 byte, 1 = min, 0 = max)
+//	record: op (1 byte) | id (8 bytes) | priority (4 bytes) | length (4 bytes) | payload (length bytes) | crc32 (4 bytes)
+//
+// The op is 1 for Enqueue and 2 for Dequeue. Every Enqueue is assigned a
+// monotonically increasing id, and a Dequeue record references the id it
+// removed; its priority and length are zero. The trailing CRC-32 (IEEE)
+// covers every preceding byte of the record. A torn or corrupt record at the
+// end of the log is discarded and truncated on Open.
+package walpq
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/XeniaPhe/xengods/pqueue"
+)
+
+const (
+	magic         = "XGPQWAL"
+	version       = 1
+	headerSize    = len(magic) + 2
+	recordHeader  = 1 + 8 + 4 + 4
+	checksumSize  = 4
+	opEnqueue     = 1
+	opDequeue     = 2
+	orderMin      = 1
+	orderMax      = 0
+	tmpFileSuffix = ".compact"
+)
+
+var emptyQueueError error
+var closedQueueError error
+var orderMismatchError error
+var invalidHeaderError error
+var failedQueueError error
+
+func init() {
+	emptyQueueError = errors.New("empty durable priority queue")
+	closedQueueError = errors.New("durable priority queue is closed")
+	failedQueueError = errors.New("durable priority queue failed to write its log")
+	orderMismatchError = errors.New("log was written with a different queue order")
+	invalidHeaderError = errors.New("invalid write-ahead log header")
+}
+
+type SyncPolicy int
+
+const (
+	SyncAlways SyncPolicy = iota
+	SyncEvery
+	SyncNever
+)
+
+type Options struct {
+	Sync             SyncPolicy
+	SyncInterval     int
+	CompactThreshold int
+}
+
+type Codec[T any] interface {
+	Encode(value T) ([]byte, error)
+	Decode(data []byte) (T, error)
+}
+
+type JSONCodec[T any] struct{}
+
+func (JSONCodec[T]) Encode(value T) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+func (JSONCodec[T]) Decode(data []byte) (T, error) {
+	var value T
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
+
+type entry[T any] struct {
+	id    uint64
+	value T
+}
+
+type DurablePQueue[T any] struct {
+	path       string
+	file       *os.File
+	pq         pqueue.PQueue[entry[T]]
+	codec      Codec[T]
+	options    Options
+	min        bool
+	nextID     uint64
+	records    int
+	unsynced   int
+	failed     error
+	compactAt  int
+	compactErr error
+}
+
+func Open[T any](path string, min bool, codec Codec[T], options ...Options) (*DurablePQueue[T], error) {
+	var opts Options
+
+	if len(options) > 0 {
+		opts = options[0]
+	}
+
+	if codec == nil {
+		codec = JSONCodec[T]{}
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d := &DurablePQueue[T]{path: path, file: file, codec: codec, options: opts, min: min}
+
+	if err := d.recover(); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (d *DurablePQueue[T]) Enqueue(value T, priority int32) error {
+	if err := d.writable(); err != nil {
+		return err
+	}
+
+	payload, err := d.codec.Encode(value)
+
+	if err != nil {
+		return err
+	}
+
+	id := d.nextID
+
+	if err := d.append(opEnqueue, id, priority, payload); err != nil {
+		return err
+	}
+
+	d.nextID++
+	d.pq.Enqueue(entry[T]{id, value}, priority)
+	d.maybeCompact()
+	return nil
+}
+
+func (d *DurablePQueue[T]) Dequeue() (pqueue.Item[T], error) {
+	if err := d.writable(); err != nil {
+		return pqueue.Item[T]{}, err
+	}
+
+	top, err := d.pq.Peek()
+
+	if err != nil {
+		return pqueue.Item[T]{}, emptyQueueError
+	}
+
+	if err := d.append(opDequeue, top.Value.id, 0, nil); err != nil {
+		return pqueue.Item[T]{}, err
+	}
+
+	d.pq.Dequeue()
+	d.maybeCompact()
+	return pqueue.Item[T]{Value: top.Value.value, Priority: top.Priority}, nil
+}
+
+func (d *DurablePQueue[T]) Peek() (pqueue.Item[T], error) {
+	top, err := d.pq.Peek()
+
+	if err != nil {
+		return pqueue.Item[T]{}, emptyQueueError
+	}
+
+	return pqueue.Item[T]{Value: top.Value.value, Priority: top.Priority}, nil
+}
+
+func (d *DurablePQueue[T]) Size() int {
+	return d.pq.Size()
+}
+
+func (d *DurablePQueue[T]) IsEmpty() bool {
+	return d.pq.IsEmpty()
+}
+
+func (d *DurablePQueue[T]) LogRecords() int {
+	return d.records
+}
+
+func (d *DurablePQueue[T]) CompactError() error {
+	return d.compactErr
+}
+
+func (d *DurablePQueue[T]) Sync() error {
+	if err := d.writable(); err != nil {
+		return err
+	}
+
+	d.unsynced = 0
+
+	if err := d.file.Sync(); err != nil {
+		d.fail(err)
+		return err
+	}
+
+	return nil
+}
+
+func (d *DurablePQueue[T]) Close() error {
+	if d.file == nil {
+		return closedQueueError
+	}
+
+	syncErr := d.file.Sync()
+	closeErr := d.file.Close()
+	d.file = nil
+	return errors.Join(syncErr, closeErr)
+}
+
+func (d *DurablePQueue[T]) Compact() error {
+	if err := d.writable(); err != nil {
+		return err
+	}
+
+	tmpPath := d.path + tmpFileSuffix
+	tmp, err := os.OpenFile(tmpPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
+
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(d.header())
+
+	for item := range d.pq.Sorted() {
+		payload, err := d.codec.Encode(item.Value.value)
+
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmpPath)
+			return err
+		}
+
+		buf.Write(encodeRecord(opEnqueue, item.Value.id, item.Priority, payload))
+	}
+
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := os.Rename(tmpPath, d.path); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+
+	d.file.Close()
+	d.file = tmp
+	d.records = d.pq.Size()
+	d.unsynced = 0
+	d.compactAt, d.compactErr = 0, nil
+
+	if err := syncDir(d.path); err != nil {
+		d.fail(err)
+		return err
+	}
+
+	return nil
+}
+
+func (d *DurablePQueue[T]) header() []byte {
+	header := make([]byte, 0, headerSize)
+	header = append(header, magic...)
+	header = append(header, version)
+
+	if d.min {
+		return append(header, orderMin)
+	}
+
+	return append(header, orderMax)
+}
+
+func (d *DurablePQueue[T]) recover() error {
+	data, err := io.ReadAll(d.file)
+
+	if err != nil {
+		return err
+	}
+
+	if len(data) < headerSize && bytes.HasPrefix(d.header(), data[:min(len(data), len(magic)+1)]) {
+		return d.initialize()
+	}
+
+	if len(data) < headerSize || string(data[:len(magic)]) != magic || data[len(magic)] != version {
+		return invalidHeaderError
+	}
+
+	if (data[len(magic)+1] == orderMin) != d.min {
+		return orderMismatchError
+	}
+
+	live := make(map[uint64]pqueue.Item[entry[T]])
+	offset := headerSize
+
+	for offset < len(data) {
+		op, id, priority, payload, size, ok := decodeRecord(data[offset:])
+
+		if !ok {
+			break
+		}
+
+		switch op {
+		case opEnqueue:
+			value, err := d.codec.Decode(payload)
+
+			if err != nil {
+				return fmt.Errorf("decoding record at offset %d: %w", offset, err)
+			}
+
+			live[id] = pqueue.Item[entry[T]]{Value: entry[T]{id, value}, Priority: priority}
+			d.nextID = max(d.nextID, id+1)
+		case opDequeue:
+			delete(live, id)
+		default:
+			return fmt.Errorf("unknown operation %d at offset %d", op, offset)
+		}
+
+		d.records++
+		offset += size
+	}
+
+	if offset < len(data) {
+		if err := d.file.Truncate(int64(offset)); err != nil {
+			return err
+		}
+	}
+
+	if _, err := d.file.Seek(int64(offset), io.SeekStart); err != nil {
+		return err
+	}
+
+	items := make([]pqueue.Item[entry[T]], 0, len(live))
+
+	for _, item := range live {
+		items = append(items, item)
+	}
+
+	d.pq = pqueue.FromItems(d.min, items...)
+	return nil
+}
+
+// A log shorter than its header was torn while being created, so it holds no
+// records and is started over.
+func (d *DurablePQueue[T]) initialize() error {
+	d.pq = pqueue.NewPQueue[entry[T]](d.min)
+
+	if err := d.rollback(0); err != nil {
+		return err
+	}
+
+	if _, err := d.file.Write(d.header()); err != nil {
+		return err
+	}
+
+	if err := d.file.Sync(); err != nil {
+		return err
+	}
+
+	return syncDir(d.path)
+}
+
+// A failed write is rolled back so that a torn record can't end up in front of
+// later ones, which recovery would then drop. If the rollback fails too, or a
+// sync fails and leaves the log's durable contents unknown, the queue refuses
+// further writes.
+func (d *DurablePQueue[T]) append(op byte, id uint64, priority int32, payload []byte) error {
+	offset, err := d.file.Seek(0, io.SeekCurrent)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := d.file.Write(encodeRecord(op, id, priority, payload)); err != nil {
+		if rollbackErr := d.rollback(offset); rollbackErr != nil {
+			d.fail(errors.Join(err, rollbackErr))
+		}
+
+		return err
+	}
+
+	d.records++
+	d.unsynced++
+
+	switch d.options.Sync {
+	case SyncAlways:
+		return d.Sync()
+	case SyncEvery:
+		if d.unsynced >= max(d.options.SyncInterval, 1) {
+			return d.Sync()
+		}
+	}
+
+	return nil
+}
+
+func (d *DurablePQueue[T]) rollback(offset int64) error {
+	if err := d.file.Truncate(offset); err != nil {
+		return err
+	}
+
+	_, err := d.file.Seek(offset, io.SeekStart)
+	return err
+}
+
+func (d *DurablePQueue[T]) writable() error {
+	if d.file == nil {
+		return closedQueueError
+	}
+
+	return d.failed
+}
+
+func (d *DurablePQueue[T]) fail(err error) {
+	d.failed = fmt.Errorf("%w: %w", failedQueueError, err)
+}
+
+func syncDir(path string) error {
+	dir, err := os.Open(filepath.Dir(path))
+
+	if err != nil {
+		return err
+	}
+
+	syncErr := dir.Sync()
+	closeErr := dir.Close()
+	return errors.Join(syncErr, closeErr)
+}
+
+// The operation that triggered an automatic compaction has already been logged,
+// so a failed compaction is not reported to it. The error is kept for
+// CompactError and compaction is retried once another threshold of records has
+// been appended.
+func (d *DurablePQueue[T]) maybeCompact() {
+	threshold := d.options.CompactThreshold
+
+	if threshold <= 0 || d.records < max(threshold, d.compactAt) || d.records < 2*d.pq.Size() {
+		return
+	}
+
+	if d.compactErr = d.Compact(); d.compactErr != nil {
+		d.compactAt = d.records + threshold
+	}
+}
+
+func encodeRecord(op byte, id uint64, priority int32, payload []byte) []byte {
+	record := make([]byte, recordHeader, recordHeader+len(payload)+checksumSize)
+	record[0] = op
+	binary.BigEndian.PutUint64(record[1:9], id)
+	binary.BigEndian.PutUint32(record[9:13], uint32(priority))
+	binary.BigEndian.PutUint32(record[13:17], uint32(len(payload)))
+	record = append(record, payload...)
+	return binary.BigEndian.AppendUint32(record, crc32.ChecksumIEEE(record))
+}
+
+func decodeRecord(data []byte) (op byte, id uint64, priority int32, payload []byte, size int, ok bool) {
+	if len(data) < recordHeader+checksumSize {
+		return 0, 0, 0, nil, 0, false
+	}
+
+	length := int(binary.BigEndian.Uint32(data[13:17]))
+	size = recordHeader + length + checksumSize
+
+	if length > len(data) || len(data) < size {
+		return 0, 0, 0, nil, 0, false
+	}
+
+	body := data[:recordHeader+length]
+
+	if crc32.ChecksumIEEE(body) != binary.BigEndian.Uint32(data[recordHeader+length:size]) {
+		return 0, 0, 0, nil, 0, false
+	}
+
+	op = data[0]
+	id = binary.BigEndian.Uint64(data[1:9])
+	priority = int32(binary.BigEndian.Uint32(data[9:13]))
+	return op, id, priority, data[recordHeader : recordHeader+length], size, true
+}
@@ -0,0 +1,316 @@
+package walpq
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type job struct {
+	Name    string
+	Retries int
+}
+
+func openTemp[T any](t *testing.T, path string, min bool, options ...Options) *DurablePQueue[T] {
+	d, err := Open[T](path, min, nil, options...)
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	return d
+}
+
+func TestDurableEnqueueDequeueRecover(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.wal")
+	d := openTemp[job](t, path, false)
+
+	_, err := d.Dequeue()
+
+	if err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	d.Enqueue(job{"low", 0}, 1)
+	d.Enqueue(job{"high", 2}, 9)
+	d.Enqueue(job{"mid", 1}, 5)
+	d.Enqueue(job{"top", 0}, 10)
+
+	item, err := d.Dequeue()
+
+	if err != nil {
+		t.Errorf("Expected no error, got '%s' instead", err.Error())
+	}
+
+	if item.Value.Name != "top" || item.Priority != 10 {
+		t.Errorf("Expected (top:10), got (%s:%d) instead", item.Value.Name, item.Priority)
+	}
+
+	if err := d.Close(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := d.Enqueue(job{}, 0); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	d = openTemp[job](t, path, false)
+	defer d.Close()
+
+	if d.Size() != 3 {
+		t.Errorf("Expected size 3, got %d instead", d.Size())
+	}
+
+	peeked, _ := d.Peek()
+
+	if peeked.Value != (job{"high", 2}) {
+		t.Errorf("Expected high, got %v instead", peeked.Value)
+	}
+
+	d.Enqueue(job{"new", 0}, 7)
+
+	for _, expected := range []string{"high", "new", "mid", "low"} {
+		item, err := d.Dequeue()
+
+		if err != nil {
+			t.Fatalf("Expected no error, got '%s' instead", err.Error())
+		}
+
+		if item.Value.Name != expected {
+			t.Errorf("Expected %s, got %s instead", expected, item.Value.Name)
+		}
+	}
+
+	if !d.IsEmpty() {
+		t.Errorf("Expected size 0, got %d instead", d.Size())
+	}
+}
+
+func TestDurableOrderMismatchAndHeader(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "queue.wal")
+	d := openTemp[int](t, path, true)
+	d.Close()
+
+	if _, err := Open[int](path, false, nil); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	garbage := filepath.Join(dir, "garbage.wal")
+	os.WriteFile(garbage, []byte("not a log file"), 0o644)
+
+	if _, err := Open[int](garbage, true, nil); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	torn := filepath.Join(dir, "torn.wal")
+	os.WriteFile(torn, []byte(magic[:4]), 0o644)
+	d = openTemp[int](t, torn, true)
+	d.Enqueue(1, 1)
+	d.Close()
+
+	if d = openTemp[int](t, torn, true); d.Size() != 1 {
+		t.Errorf("Expected size 1, got %d instead", d.Size())
+	}
+
+	d.Close()
+}
+
+func TestDurableTornTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.wal")
+	d := openTemp[string](t, path, true)
+	d.Enqueue("a", 1)
+	d.Enqueue("b", 2)
+	d.Close()
+
+	info, _ := os.Stat(path)
+	intact := info.Size()
+
+	file, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	file.Write(encodeRecord(opEnqueue, 99, 0, []byte(`"torn"`))[:10])
+	file.Close()
+
+	d = openTemp[string](t, path, true)
+
+	if d.Size() != 2 {
+		t.Errorf("Expected size 2, got %d instead", d.Size())
+	}
+
+	info, _ = os.Stat(path)
+
+	if info.Size() != intact {
+		t.Errorf("Expected the log to be truncated to %d bytes, got %d instead", intact, info.Size())
+	}
+
+	d.Enqueue("c", 0)
+	d.Close()
+
+	d = openTemp[string](t, path, true)
+	defer d.Close()
+
+	item, _ := d.Peek()
+
+	if item.Value != "c" || d.Size() != 3 {
+		t.Errorf("Expected c with size 3, got %s with size %d instead", item.Value, d.Size())
+	}
+}
+
+func TestDurableFailedWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.wal")
+	d := openTemp[string](t, path, true)
+	d.Enqueue("a", 1)
+
+	offset, _ := d.file.Seek(0, io.SeekCurrent)
+	d.file.Write(encodeRecord(opEnqueue, 7, 0, []byte(`"torn"`))[:10])
+
+	if err := d.rollback(offset); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	d.Enqueue("b", 2)
+	writable := d.file
+	d.file, _ = os.Open(path)
+
+	if err := d.Enqueue("c", 0); err == nil {
+		t.Error("Expected an error, got nothing")
+	}
+
+	if err := d.Enqueue("d", 0); !errors.Is(err, failedQueueError) {
+		t.Errorf("Expected the queue to be failed, got %v instead", err)
+	}
+
+	if _, err := d.Dequeue(); !errors.Is(err, failedQueueError) {
+		t.Errorf("Expected the queue to be failed, got %v instead", err)
+	}
+
+	d.Close()
+	writable.Close()
+
+	d = openTemp[string](t, path, true)
+	defer d.Close()
+
+	if d.Size() != 2 {
+		t.Errorf("Expected size 2, got %d instead", d.Size())
+	}
+}
+
+func TestDurableCompaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.wal")
+	d := openTemp[int](t, path, true, Options{Sync: SyncNever, CompactThreshold: 50})
+
+	for i := range 200 {
+		d.Enqueue(i, int32(i))
+
+		if i%2 == 1 {
+			d.Dequeue()
+		}
+	}
+
+	if d.LogRecords() >= 300 {
+		t.Errorf("Expected automatic compaction, got %d log records", d.LogRecords())
+	}
+
+	if err := d.Compact(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if d.LogRecords() != d.Size() {
+		t.Errorf("Expected %d log records, got %d instead", d.Size(), d.LogRecords())
+	}
+
+	size := d.Size()
+	d.Enqueue(-1, -1)
+	d.Close()
+
+	if _, err := os.Stat(path + tmpFileSuffix); !os.IsNotExist(err) {
+		t.Error("Expected the temporary compaction file to be gone")
+	}
+
+	d = openTemp[int](t, path, true)
+	defer d.Close()
+
+	if d.Size() != size+1 {
+		t.Errorf("Expected size %d, got %d instead", size+1, d.Size())
+	}
+
+	previous := int32(-2)
+
+	for !d.IsEmpty() {
+		item, _ := d.Dequeue()
+
+		if item.Priority < previous {
+			t.Fatalf("Expected ascending priorities, got %d after %d", item.Priority, previous)
+		}
+
+		previous = item.Priority
+	}
+}
+
+func TestDurableFailedCompaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.wal")
+	d := openTemp[int](t, path, true, Options{Sync: SyncNever, CompactThreshold: 4})
+	os.Mkdir(path+tmpFileSuffix, 0o755)
+
+	for i := range 4 {
+		if err := d.Enqueue(i, int32(i)); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	for range 3 {
+		if _, err := d.Dequeue(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if d.CompactError() == nil || d.LogRecords() != 7 {
+		t.Errorf("Expected a recorded compaction error and 7 log records, got %v and %d", d.CompactError(), d.LogRecords())
+	}
+
+	os.Remove(path + tmpFileSuffix)
+	d.Enqueue(4, 4)
+	d.Dequeue()
+	d.Enqueue(5, 5)
+
+	if d.CompactError() != nil || d.LogRecords() != 2 {
+		t.Errorf("Expected compaction to be retried, got %v and %d log records", d.CompactError(), d.LogRecords())
+	}
+
+	d.Close()
+
+	if d = openTemp[int](t, path, true); d.Size() != 2 {
+		t.Errorf("Expected size 2, got %d instead", d.Size())
+	}
+
+	d.Close()
+}
+
+func TestDurableSyncPolicies(t *testing.T) {
+	dir := t.TempDir()
+
+	for i, options := range []Options{{Sync: SyncAlways}, {Sync: SyncEvery, SyncInterval: 3}, {Sync: SyncNever}} {
+		path := filepath.Join(dir, string(rune('a'+i))+".wal")
+		d := openTemp[int](t, path, false, options)
+
+		for j := range 10 {
+			if err := d.Enqueue(j, int32(j)); err != nil {
+				t.Errorf("Expected no error, got '%s' instead", err.Error())
+			}
+		}
+
+		if err := d.Sync(); err != nil {
+			t.Errorf("Expected no error, got '%s' instead", err.Error())
+		}
+
+		d.Close()
+		d = openTemp[int](t, path, false, options)
+
+		if d.Size() != 10 {
+			t.Errorf("Expected size 10, got %d instead", d.Size())
+		}
+
+		d.Close()
+	}
+}